@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// previewCleanup lists the template files cleanupFiles removes for config. The
+// template is copied to a temporary directory and cleaned up there, so the list
+// is exactly what generation deletes; directories removed as a whole are listed
+// once with a trailing slash.
+func previewCleanup(config *ProjectConfig) ([]string, error) {
+	tempDir, err := os.MkdirTemp("", "go-skeleton-preview-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	preview := *config
+	preview.ProjectPath = filepath.Join(tempDir, "project")
+	if err := copyTemplate(context.Background(), &preview); err != nil {
+		return nil, err
+	}
+	if err := cleanupFiles(&preview); err != nil {
+		return nil, err
+	}
+
+	kept, err := projectFiles(preview.ProjectPath)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := removedTemplateFiles(kept)
+	if err != nil {
+		return nil, err
+	}
+
+	return collapseRemovedDirs(removed, kept), nil
+}
+
+// collapseRemovedDirs replaces the files of a directory nothing is kept from by
+// the directory itself, keeping the order of removed
+func collapseRemovedDirs(removed, kept []string) []string {
+	keptDirs := map[string]bool{}
+	for _, file := range kept {
+		for dir := path.Dir(file); dir != "."; dir = path.Dir(dir) {
+			keptDirs[dir] = true
+		}
+	}
+
+	entries := []string{}
+	listed := map[string]bool{}
+	for _, file := range removed {
+		entry := file
+		for dir := path.Dir(file); dir != "."; dir = path.Dir(dir) {
+			if !keptDirs[dir] {
+				entry = dir + "/"
+			}
+		}
+		if !listed[entry] {
+			listed[entry] = true
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// printCleanupPreview shows what the chosen options leave out of the template
+func printCleanupPreview(config *ProjectConfig) {
+	removed, err := previewCleanup(config)
+	if err != nil {
+		fmt.Printf(ColorYellow+"⚠ Could not preview the removed files: %v\n"+ColorReset, err)
+		return
+	}
+	if len(removed) == 0 {
+		return
+	}
+
+	fmt.Println(ColorBlue + "🗑  Template files removed for this configuration:" + ColorReset)
+	for _, entry := range removed {
+		fmt.Println("  - " + entry)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// DevService is a service of the devcontainer's compose file as the app
+// container reaches it. The compose template and .env.devcontainer both read
+// it, so the credentials the services start with are the ones the app uses.
+type DevService struct {
+	Host     string
+	Port     int // container port, the host port is only published for tools outside
+	User     string
+	Password string
+	Database string
+}
+
+// Addr is the service's host:port
+func (s DevService) Addr() string {
+	return s.Host + ":" + strconv.Itoa(s.Port)
+}
+
+// DevDB is the devcontainer's database. The app joins its network, so it's
+// reached on localhost.
+func (c *ProjectConfig) DevDB() DevService {
+	db := DevService{Host: "localhost", Port: defaultDBPorts[c.Database], Database: c.DBName()}
+	switch c.Database {
+	case "mysql", "mariadb":
+		db.User, db.Password = "root", "root"
+	case "postgresql":
+		db.User, db.Password = "postgres", "postgres"
+	case "cockroachdb":
+		db.User = "root"
+	}
+	return db
+}
+
+// DevRedis is the devcontainer's Redis, without a password
+func (c *ProjectConfig) DevRedis() DevService {
+	return DevService{Host: "redis", Port: DefaultRedisPort}
+}
+
+// DevRabbitMQ is the devcontainer's RabbitMQ
+func (c *ProjectConfig) DevRabbitMQ() DevService {
+	return DevService{Host: "rabbitmq", Port: DefaultRabbitMQPort, User: "guest", Password: "guest"}
+}
+
+// DevLogMongo is the MongoDB storing the logs next to another database
+func (c *ProjectConfig) DevLogMongo() DevService {
+	return DevService{Host: "mongodb", Port: DefaultMongoPort, Database: c.DBName()}
+}
+
+// devcontainerEnv are the settings of .env.devcontainer connecting the app
+// to the services of the devcontainer's compose file
+func devcontainerEnv(config *ProjectConfig) []envValue {
+	env := []envValue{}
+
+	db := config.DevDB()
+	switch config.Database {
+	case "mysql", "mariadb":
+		env = append(env,
+			envValue{"MYSQL_URI", fmt.Sprintf("%s:%s@tcp(%s)/%s?parseTime=true", db.User, db.Password, db.Addr(), db.Database)},
+			envValue{"MYSQL_USERNAME", db.User},
+			envValue{"MYSQL_PASSWORD", db.Password},
+			envValue{"MYSQL_HOST", db.Host},
+			envValue{"MYSQL_PORT", strconv.Itoa(db.Port)},
+			envValue{"MYSQL_DATABASE_NAME", db.Database},
+		)
+	case "mongodb":
+		env = append(env,
+			envValue{"MONGODB_URI", "mongodb://" + db.Addr()},
+			envValue{"MONGODB_DATABASE_NAME", db.Database},
+		)
+	}
+	// PostgreSQL's and CockroachDB's URI is set by setPostgresURI, for both env files
+
+	if config.usesLogMongo() {
+		mongo := config.DevLogMongo()
+		env = append(env,
+			envValue{"MONGODB_URI", "mongodb://" + mongo.Addr()},
+			envValue{"MONGODB_DATABASE_NAME", mongo.Database},
+		)
+	}
+	if config.UseRedis {
+		redis := config.DevRedis()
+		env = append(env,
+			envValue{"REDIS_HOST", redis.Addr()},
+			envValue{"REDIS_PASSWORD", redis.Password},
+		)
+	}
+	if config.UseRabbitMQ {
+		rabbitMQ := config.DevRabbitMQ()
+		env = append(env, envValue{"RABBITMQ_URI", fmt.Sprintf("amqp://%s:%s@%s/", rabbitMQ.User, rabbitMQ.Password, rabbitMQ.Addr())})
+	}
+	return env
+}
+
+// updateDevcontainerEnv fills .env.devcontainer with devcontainerEnv, the
+// settings the wiring removed stay removed
+func updateDevcontainerEnv(config *ProjectConfig) error {
+	path := filepath.Join(config.ProjectPath, ".devcontainer/.env.devcontainer")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	envContent := string(content)
+	for _, v := range devcontainerEnv(config) {
+		envContent = setEnvValue(envContent, v.Name, v.Value)
+	}
+	return os.WriteFile(path, []byte(envContent), 0644)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// maxDiffCells bounds the line comparison table, bigger files are shown as
+// replaced as a whole
+const maxDiffCells = 4_000_000
+
+// diffLine is a line of the edit script turning one file into the other, kind
+// is ' ' for a kept line, '-' for a removed one and '+' for an added one
+type diffLine struct {
+	kind  byte
+	text  string
+	aLine int // line number in the old file, after the line for additions
+	bLine int // line number in the new file, after the line for removals
+}
+
+// unifiedDiff returns the changes from a to b in the unified diff format, ""
+// when they are equal
+func unifiedDiff(aName, bName, a, b string) string {
+	if a == b {
+		return ""
+	}
+
+	script := diffLines(splitLines(a), splitLines(b))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", aName, bName)
+	for _, hunk := range diffHunks(script) {
+		lines := script[hunk[0]:hunk[1]]
+
+		// A side without lines in the hunk is numbered by the line before it
+		aStart, aCount, bStart, bCount := lines[0].aLine, 0, lines[0].bLine, 0
+		for _, line := range lines {
+			if line.kind != '+' {
+				if aCount == 0 {
+					aStart = line.aLine
+				}
+				aCount++
+			}
+			if line.kind != '-' {
+				if bCount == 0 {
+					bStart = line.bLine
+				}
+				bCount++
+			}
+		}
+
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(aStart, aCount), hunkRange(bStart, bCount))
+		for _, line := range lines {
+			out.WriteByte(line.kind)
+			out.WriteString(line.text)
+			out.WriteByte('\n')
+		}
+	}
+
+	return out.String()
+}
+
+// hunkRange formats the start and length of a hunk side, a single line is
+// written as its number alone
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// splitLines splits content into lines without their line endings
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// diffLines builds the shortest edit script from a to b out of their longest
+// common subsequence of lines
+func diffLines(a, b []string) []diffLine {
+	// Common leading and trailing lines keep the table small
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	script := []diffLine{}
+	for i := 0; i < prefix; i++ {
+		script = append(script, diffLine{' ', a[i], i + 1, i + 1})
+	}
+
+	n, m := len(midA), len(midB)
+	if n*m > maxDiffCells {
+		for i, line := range midA {
+			script = append(script, diffLine{'-', line, prefix + i + 1, prefix})
+		}
+		for j, line := range midB {
+			script = append(script, diffLine{'+', line, prefix + n, prefix + j + 1})
+		}
+	} else {
+		// lcs[i][j] is the common subsequence length of midA[i:] and midB[j:]
+		lcs := make([][]int32, n+1)
+		for i := range lcs {
+			lcs[i] = make([]int32, m+1)
+		}
+		for i := n - 1; i >= 0; i-- {
+			for j := m - 1; j >= 0; j-- {
+				if midA[i] == midB[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+
+		i, j := 0, 0
+		for i < n || j < m {
+			switch {
+			case i < n && j < m && midA[i] == midB[j]:
+				script = append(script, diffLine{' ', midA[i], prefix + i + 1, prefix + j + 1})
+				i++
+				j++
+			case i < n && (j == m || lcs[i+1][j] >= lcs[i][j+1]):
+				script = append(script, diffLine{'-', midA[i], prefix + i + 1, prefix + j})
+				i++
+			default:
+				script = append(script, diffLine{'+', midB[j], prefix + i, prefix + j + 1})
+				j++
+			}
+		}
+	}
+
+	for k := suffix; k > 0; k-- {
+		script = append(script, diffLine{' ', a[len(a)-k], len(a) - k + 1, len(b) - k + 1})
+	}
+
+	return script
+}
+
+// diffHunks returns the [start, end) ranges of script shown as hunks: every
+// change with diffContext lines around it, merging changes that close together
+func diffHunks(script []diffLine) [][2]int {
+	hunks := [][2]int{}
+	for i, line := range script {
+		if line.kind == ' ' {
+			continue
+		}
+		start, end := max(i-diffContext, 0), min(i+1+diffContext, len(script))
+		if last := len(hunks) - 1; last >= 0 && start <= hunks[last][1] {
+			hunks[last][1] = end
+			continue
+		}
+		hunks = append(hunks, [2]int{start, end})
+	}
+	return hunks
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// envDocsStart and envDocsEnd delimit the env var table in the README
+const (
+	envDocsStart = "<!-- env-vars:start -->"
+	envDocsEnd   = "<!-- env-vars:end -->"
+)
+
+// envVar is a setting read by the generated Config
+type envVar struct {
+	Name        string
+	Default     string
+	Required    bool
+	Description string
+}
+
+// updateEnvDocs writes the table of the env vars read by the generated Config
+// into README.md, so it only lists the options that were kept
+func updateEnvDocs(config *ProjectConfig) error {
+	vars, err := collectEnvVars(filepath.Join(config.ProjectPath, "config/config.go"))
+	if err != nil {
+		return err
+	}
+
+	return replaceMarked(filepath.Join(config.ProjectPath, "README.md"), envDocsStart, envDocsEnd, envTable(vars))
+}
+
+// replaceMarked replaces the lines between the start and end markers of the
+// file with content, keeping the markers
+func replaceMarked(path, start, end, content string) error {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	before, rest, found := strings.Cut(string(file), start)
+	if !found {
+		return fmt.Errorf("%s: marker %q not found", filepath.Base(path), start)
+	}
+	_, after, found := strings.Cut(rest, end)
+	if !found {
+		return fmt.Errorf("%s: marker %q not found", filepath.Base(path), end)
+	}
+
+	return os.WriteFile(path, []byte(before+start+"\n"+content+end+after), 0644)
+}
+
+// collectEnvVars reads the env and desc tags of Config, following the option
+// structs it embeds, in declaration order
+func collectEnvVars(configPath string) ([]envVar, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), configPath, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	structs := map[string]*ast.StructType{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		if spec, ok := node.(*ast.TypeSpec); ok {
+			if structType, ok := spec.Type.(*ast.StructType); ok {
+				structs[spec.Name.Name] = structType
+			}
+		}
+		return true
+	})
+	if structs["Config"] == nil {
+		return nil, fmt.Errorf("%s: no type Config struct declaration", configPath)
+	}
+
+	vars := []envVar{}
+	var collect func(name string) error
+	collect = func(name string) error {
+		for _, field := range structs[name].Fields.List {
+			// Embedded option structs declared in the same file
+			if embedded, ok := field.Type.(*ast.Ident); ok && len(field.Names) == 0 && structs[embedded.Name] != nil {
+				if err := collect(embedded.Name); err != nil {
+					return err
+				}
+				continue
+			}
+			if field.Tag == nil {
+				continue
+			}
+
+			tag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return err
+			}
+			env, ok := reflect.StructTag(tag).Lookup("env")
+			if !ok {
+				continue
+			}
+
+			options := strings.Split(env, ",")
+			v := envVar{Name: options[0], Description: reflect.StructTag(tag).Get("desc")}
+			for _, option := range options[1:] {
+				if value, ok := strings.CutPrefix(option, "default="); ok {
+					v.Default = value
+				}
+				if option == "required" {
+					v.Required = true
+				}
+			}
+			vars = append(vars, v)
+		}
+		return nil
+	}
+
+	return vars, collect("Config")
+}
+
+// envTable renders vars as a Markdown table
+func envTable(vars []envVar) string {
+	var table strings.Builder
+	table.WriteString("| Variable | Default | Required | Description |\n")
+	table.WriteString("| --- | --- | --- | --- |\n")
+	for _, v := range vars {
+		defaultValue, required := "", ""
+		if v.Default != "" {
+			defaultValue = "`" + v.Default + "`"
+		}
+		if v.Required {
+			required = "yes"
+		}
+		fmt.Fprintf(&table, "| `%s` | %s | %s | %s |\n", v.Name, defaultValue, required, strings.ReplaceAll(v.Description, "|", `\|`))
+	}
+	return table.String()
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// examplesDir holds the bundled examples, finished projects with their own
+// go.mod. A directory with a go.mod can't be embedded like the template, so
+// --example reads them from the checkout the generator runs in.
+const examplesDir = "examples"
+
+// availableExamples lists the example names, the directories of examplesDir with a go.mod
+func availableExamples() ([]string, error) {
+	entries, err := os.ReadDir(examplesDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	examples := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(examplesDir, entry.Name(), "go.mod")); err == nil {
+			examples = append(examples, entry.Name())
+		}
+	}
+	sort.Strings(examples)
+
+	return examples, nil
+}
+
+// validateExample checks name is one of availableExamples, listing them when it isn't
+func validateExample(name string) error {
+	examples, err := availableExamples()
+	if err != nil {
+		return err
+	}
+	if len(examples) == 0 {
+		return fmt.Errorf("invalid --example %q, no examples found in ./%s (run the generator from its repository)", name, examplesDir)
+	}
+	if !contains(examples, name) {
+		return fmt.Errorf("invalid --example %q, expected one of: %s", name, strings.Join(examples, ", "))
+	}
+
+	return nil
+}
+
+// exampleModule reads the module path of an example's go.mod
+func exampleModule(name string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(examplesDir, name, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.TrimSpace(module), nil
+		}
+	}
+
+	return "", fmt.Errorf("%s/%s/go.mod declares no module", examplesDir, name)
+}
+
+// exampleConfiguration only asks for the project name and module path, the
+// example decides everything else
+func exampleConfiguration(opts *CLIOptions) *ProjectConfig {
+	config := &ProjectConfig{
+		ProjectName: opts.Name,
+		ModulePath:  opts.Module,
+		Example:     opts.Example,
+	}
+	config.applyCLIOptions(opts)
+
+	if opts.Interactive {
+		reader := bufio.NewReader(os.Stdin)
+		if config.ProjectName == "" {
+			config.ProjectName = promptString(reader, "What is your project name?", "my-go-api")
+		}
+		if config.ModulePath == "" {
+			config.ModulePath = promptString(reader, "What is your Go module path?", placeholderModule(config.ProjectName))
+		}
+	}
+
+	if config.ProjectPath == "" {
+		config.ProjectPath = "./" + config.ProjectName
+	}
+	if config.ModulePath == "" {
+		config.ModulePath = placeholderModule(config.ProjectName)
+	}
+
+	return config
+}
+
+// createExampleProject copies an example instead of the template. The example
+// is a finished project, so only its module path is changed.
+func createExampleProject(ctx context.Context, config *ProjectConfig) error {
+	fmt.Println(ColorBlue + "🔧 Creating project from the " + config.Example + " example..." + ColorReset)
+
+	if err := os.MkdirAll(config.ProjectPath, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if err := generationStep(ctx, "[1/2] Copying example files..."); err != nil {
+		return err
+	}
+	if err := copyTree(ctx, os.DirFS(examplesDir), config.Example, config.ProjectPath, nil); err != nil {
+		return fmt.Errorf("failed to copy example: %w", err)
+	}
+
+	if err := generationStep(ctx, "[2/2] Updating module paths..."); err != nil {
+		return err
+	}
+	if err := updateModulePaths(config); err != nil {
+		return fmt.Errorf("failed to update module paths: %w", err)
+	}
+
+	if config.IgnoreManifest {
+		if err := gitignoreManifest(config.ProjectPath); err != nil {
+			return fmt.Errorf("failed to update .gitignore: %w", err)
+		}
+	}
+	if err := writeManifest(config); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	if config.InitGit {
+		if err := initGitRepository(ctx, config); err != nil {
+			return fmt.Errorf("failed to initialize git: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultGenerationLogFile is written inside the project when --generate-log is set
+const DefaultGenerationLogFile = ".skeleton-generate.log"
+
+// GenerationLog records who generated a project, with which options and what came out of it
+type GenerationLog struct {
+	Timestamp   time.Time      `json:"timestamp"`
+	User        string         `json:"user"`
+	ToolVersion string         `json:"tool_version"`
+	Config      *ProjectConfig `json:"config"`
+	Created     []string       `json:"created"`
+	Removed     []string       `json:"removed"`
+}
+
+// generationLogPath resolves where the generation log goes, or "" when it's disabled
+func generationLogPath(opts *CLIOptions, config *ProjectConfig) string {
+	if opts.LogFile != "" {
+		return opts.LogFile
+	}
+	if opts.GenerateLog {
+		return filepath.Join(config.ProjectPath, DefaultGenerationLogFile)
+	}
+	return ""
+}
+
+// writeGenerationLog compares the generated project with the template and writes
+// the outcome as JSON to path
+func writeGenerationLog(path string, config *ProjectConfig) error {
+	created, err := projectFiles(config.ProjectPath)
+	if err != nil {
+		return err
+	}
+
+	removed, err := removedTemplateFiles(created)
+	if err != nil {
+		return err
+	}
+
+	entry := &GenerationLog{
+		Timestamp:   time.Now().UTC(),
+		User:        currentUser(),
+		ToolVersion: toolVersion(),
+		Config:      config,
+		Created:     created,
+		Removed:     removed,
+	}
+
+	return saveGenerationLog(path, entry)
+}
+
+// removedTemplateFiles lists the template files, by their generated name, that
+// are not among the created project files
+func removedTemplateFiles(created []string) ([]string, error) {
+	generated := map[string]bool{}
+	for _, file := range created {
+		generated[file] = true
+	}
+
+	ignore, err := loadIgnoreRules(templateFS, "template")
+	if err != nil {
+		return nil, err
+	}
+
+	removed := []string{}
+	err = fs.WalkDir(templateFS, "template", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == "template" {
+			return err
+		}
+		relPath, err := filepath.Rel("template", path)
+		if err != nil {
+			return err
+		}
+		// Ignored files were never copied, they aren't removed either
+		if ignore.ignored(filepath.ToSlash(relPath), d.IsDir()) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath = strings.TrimSuffix(filepath.ToSlash(relPath), templateSuffix)
+		if !generated[relPath] {
+			removed = append(removed, relPath)
+		}
+		return nil
+	})
+	sort.Strings(removed)
+
+	return removed, err
+}
+
+func saveGenerationLog(path string, entry *GenerationLog) error {
+	content, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(content, '\n'), 0644)
+}
+
+// hashFiles returns the sha256 of each file, relative to projectPath
+func hashFiles(projectPath string, files []string) (map[string]string, error) {
+	hashes := make(map[string]string, len(files))
+	for _, file := range files {
+		hash, err := hashFile(filepath.Join(projectPath, file))
+		if err != nil {
+			return nil, err
+		}
+		hashes[file] = hash
+	}
+	return hashes, nil
+}
+
+func hashFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// projectFiles lists every file of the generated project, relative to its root
+func projectFiles(projectPath string) ([]string, error) {
+	files := []string{}
+
+	err := filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == DefaultGenerationLogFile || d.Name() == ManifestFile {
+			return nil
+		}
+		relPath, err := filepath.Rel(projectPath, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(relPath))
+		return nil
+	})
+
+	sort.Strings(files)
+	return files, err
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// toolVersion is the generator's module version, "(devel)" when run from a checkout
+func toolVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
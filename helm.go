@@ -0,0 +1,33 @@
+package main
+
+import "path/filepath"
+
+// helmDir holds the chart written with --helm
+const helmDir = "deploy/helm"
+
+// helmClusterEnv points the env vars of the stores deployed as subcharts to
+// the release's services, the values are rendered with tpl by the chart
+var helmClusterEnv = map[string]string{
+	"REDIS_HOST":     "{{ .Release.Name }}-redis-master:6379",
+	"REDIS_PASSWORD": "{{ .Values.redis.auth.password }}",
+	"RABBITMQ_URI":   "amqp://{{ .Values.rabbitmq.auth.username }}:{{ .Values.rabbitmq.auth.password }}@{{ .Release.Name }}-rabbitmq:5672/",
+}
+
+// updateHelmValues lists the env vars read by the generated Config in the
+// env and secretEnv maps of the chart's values.yaml
+func updateHelmValues(config *ProjectConfig) error {
+	env, secretEnv, err := deployEnv(config)
+	if err != nil {
+		return err
+	}
+	for _, vars := range [][]envValue{env, secretEnv} {
+		for i, v := range vars {
+			if clusterValue, ok := helmClusterEnv[v.Name]; ok {
+				vars[i].Value = clusterValue
+			}
+		}
+	}
+
+	values := "env:\n" + yamlEnvMap(env, "  ") + "secretEnv:\n" + yamlEnvMap(secretEnv, "  ")
+	return replaceMarked(filepath.Join(config.ProjectPath, helmDir, "values.yaml"), envVarsStart, envVarsEnd, values)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// errInterrupted stops the generation when Ctrl-C is pressed
+var errInterrupted = errors.New("generation interrupted")
+
+// errTimedOut stops the generation when it takes longer than --timeout
+var errTimedOut = errors.New("generation timed out")
+
+// onGenerationStep runs before each step is announced, tests use it to
+// interrupt the generation at a given step
+var onGenerationStep = func(ctx context.Context, step string) {}
+
+// createProject generates the project, removing what it wrote when a step
+// fails, Ctrl-C is pressed or it outlasts config.Timeout, so no half generated
+// project is left behind
+func createProject(config *ProjectConfig) error {
+	return createAndCheckProject(config, nil)
+}
+
+// createAndCheckProject is createProject followed by check, like the --verify
+// build, within the same Ctrl-C and config.Timeout. The project is removed when
+// check is interrupted or times out, a check failing on its own keeps it so
+// the failure can be looked into.
+func createAndCheckProject(config *ProjectConfig, check func(ctx context.Context) error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
+	rollback, err := prepareRollback(config.ProjectPath)
+	if err != nil {
+		return err
+	}
+
+	var trace *generationTrace
+	if config.TraceGeneration {
+		trace = &generationTrace{}
+		ctx = withGenerationTrace(ctx, trace)
+	}
+
+	if err := generateProject(ctx, config); err != nil {
+		return rollbackAfter(err, rollback)
+	}
+	if check != nil {
+		if err := check(ctx); err != nil {
+			if interruptErr := checkInterrupt(ctx); interruptErr != nil {
+				return rollbackAfter(interruptErr, rollback)
+			}
+			return err
+		}
+	}
+
+	if trace != nil {
+		trace.report(os.Stdout, time.Now())
+	}
+	return nil
+}
+
+// rollbackAfter removes the partial project after the generation failed with err
+func rollbackAfter(err error, rollback func() error) error {
+	if rollbackErr := rollback(); rollbackErr != nil {
+		return fmt.Errorf("%w (removing the partial project failed: %v)", err, rollbackErr)
+	}
+	return err
+}
+
+// prepareRollback returns the function undoing the generation into
+// projectPath: a directory the generator creates is removed, in an existing
+// one only the new entries are, like .git is kept with --here
+func prepareRollback(projectPath string) (func() error, error) {
+	entries, err := os.ReadDir(projectPath)
+	if os.IsNotExist(err) {
+		return func() error { return os.RemoveAll(projectPath) }, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]bool{}
+	for _, entry := range entries {
+		existing[entry.Name()] = true
+	}
+	return func() error {
+		entries, err := os.ReadDir(projectPath)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if existing[entry.Name()] {
+				continue
+			}
+			if err := os.RemoveAll(filepath.Join(projectPath, entry.Name())); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+// generationStep announces a step of the generation, unless it was
+// interrupted
+func generationStep(ctx context.Context, step string) error {
+	onGenerationStep(ctx, step)
+	if err := checkInterrupt(ctx); err != nil {
+		return err
+	}
+	traceFrom(ctx).mark(step)
+	fmt.Println("  " + step)
+	return nil
+}
+
+// checkInterrupt returns errInterrupted once Ctrl-C was pressed and
+// errTimedOut once --timeout is over
+func checkInterrupt(ctx context.Context) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return errTimedOut
+	case ctx.Err() != nil:
+		return errInterrupted
+	}
+	return nil
+}
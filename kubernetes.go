@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/subosito/gotenv"
+)
+
+// k8sDir holds the plain manifests written with --k8s
+const k8sDir = "deploy/k8s"
+
+// envVarsStart and envVarsEnd delimit the env vars in the deployment files
+const (
+	envVarsStart = "# env-vars:start"
+	envVarsEnd   = "# env-vars:end"
+)
+
+// secretEnvSuffixes mark the env vars holding credentials, they go in a
+// Secret instead of the ConfigMap
+var secretEnvSuffixes = []string{"_URI", "_PASSWORD", "_KEYS"}
+
+// envValue is an env var set on the deployed API
+type envValue struct {
+	Name  string
+	Value string
+}
+
+// KubernetesName is the project name as a Helm chart and Kubernetes resource
+// name: lowercase letters, digits and dashes
+func (c *ProjectConfig) KubernetesName() string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '-'
+		}
+	}, c.ProjectName)
+	return strings.Trim(name, "-")
+}
+
+// deployEnv returns the env vars read by the generated Config with the values
+// of .env.example or the Config defaults, split into the plain ones and the
+// credentials
+func deployEnv(config *ProjectConfig) (env, secretEnv []envValue, err error) {
+	vars, err := collectEnvVars(filepath.Join(config.ProjectPath, "config/config.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+	example, err := gotenv.Read(filepath.Join(config.ProjectPath, ".env.example"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, v := range vars {
+		value, ok := example[v.Name]
+		if !ok {
+			value = v.Default
+		}
+		if isSecretEnv(v.Name) {
+			secretEnv = append(secretEnv, envValue{v.Name, value})
+		} else {
+			env = append(env, envValue{v.Name, value})
+		}
+	}
+	return env, secretEnv, nil
+}
+
+// isSecretEnv reports whether the env var name holds credentials
+func isSecretEnv(name string) bool {
+	for _, suffix := range secretEnvSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// yamlEnvMap renders env as the entries of a YAML map indented by indent
+func yamlEnvMap(env []envValue, indent string) string {
+	var out strings.Builder
+	for _, v := range env {
+		fmt.Fprintf(&out, "%s%s: %s\n", indent, v.Name, strconv.Quote(v.Value))
+	}
+	return out.String()
+}
+
+// updateK8sEnv writes the env vars read by the generated Config in the
+// ConfigMap and Secret of the plain manifests
+func updateK8sEnv(config *ProjectConfig) error {
+	env, secretEnv, err := deployEnv(config)
+	if err != nil {
+		return err
+	}
+
+	if err := replaceMarked(filepath.Join(config.ProjectPath, k8sDir, "configmap.yaml"), envVarsStart, envVarsEnd, yamlEnvMap(env, "  ")); err != nil {
+		return err
+	}
+	return replaceMarked(filepath.Join(config.ProjectPath, k8sDir, "secret.yaml"), envVarsStart, envVarsEnd, yamlEnvMap(secretEnv, "  "))
+}
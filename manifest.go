@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ManifestFile lists every generated file with its hash, it is written in each project
+const ManifestFile = ".skeleton-manifest.json"
+
+// Manifest records the generated content of a project. --update-existing uses
+// the hashes to tell user changes from template changes, --verify to check the
+// files on disk are the ones generated.
+type Manifest struct {
+	GeneratedAt time.Time         `json:"generated_at"`
+	ToolVersion string            `json:"tool_version"`
+	Config      *ProjectConfig    `json:"config"`
+	Files       map[string]string `json:"files"` // relative path → sha256
+}
+
+// writeManifest hashes every file of the project into its manifest
+func writeManifest(config *ProjectConfig) error {
+	files, err := projectFiles(config.ProjectPath)
+	if err != nil {
+		return err
+	}
+
+	hashes, err := hashFiles(config.ProjectPath, files)
+	if err != nil {
+		return err
+	}
+
+	manifest := &Manifest{
+		GeneratedAt: time.Now().UTC(),
+		ToolVersion: toolVersion(),
+		Config:      config,
+		Files:       hashes,
+	}
+
+	return saveManifest(config.ProjectPath, manifest)
+}
+
+func saveManifest(projectPath string, manifest *Manifest) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(projectPath, ManifestFile), append(content, '\n'), 0644)
+}
+
+func loadManifest(projectPath string) (*Manifest, error) {
+	path := filepath.Join(projectPath, ManifestFile)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	if manifest.Config == nil || manifest.Files == nil {
+		return nil, fmt.Errorf("manifest %s has no config or file hashes", path)
+	}
+	return manifest, nil
+}
+
+// verifyManifest returns the files that are missing or differ from the manifest
+func verifyManifest(projectPath string) ([]string, error) {
+	manifest, err := loadManifest(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	mismatches := []string{}
+	for file, want := range manifest.Files {
+		got, err := hashFile(filepath.Join(projectPath, file))
+		if os.IsNotExist(err) {
+			mismatches = append(mismatches, file+" (missing)")
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if got != want {
+			mismatches = append(mismatches, file+" (modified)")
+		}
+	}
+
+	return mismatches, nil
+}
+
+// gitignoreManifest keeps the manifest out of the project's commits
+func gitignoreManifest(projectPath string) error {
+	path := filepath.Join(projectPath, ".gitignore")
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString("\n# go-skeleton generation manifest\n" + ManifestFile + "\n")
+	return err
+}
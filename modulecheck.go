@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// findModuleCollisions returns the go.mod files already declaring module in
+// the directories above projectPath and in the directories next to it, where
+// scaffolding many services usually happens. It's best effort: directories and
+// files that can't be read are skipped.
+func findModuleCollisions(projectPath, module string) []string {
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil
+	}
+
+	candidates := []string{}
+	parent := filepath.Dir(absPath)
+	if siblings, err := os.ReadDir(parent); err == nil {
+		for _, sibling := range siblings {
+			if sibling.IsDir() && sibling.Name() != filepath.Base(absPath) {
+				candidates = append(candidates, filepath.Join(parent, sibling.Name(), "go.mod"))
+			}
+		}
+	}
+	for dir := parent; ; dir = filepath.Dir(dir) {
+		candidates = append(candidates, filepath.Join(dir, "go.mod"))
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	collisions := []string{}
+	for _, goMod := range candidates {
+		if declaredModule(goMod) == module {
+			collisions = append(collisions, goMod)
+		}
+	}
+
+	return collisions
+}
+
+// declaredModule returns the module path of a go.mod file, empty when it
+// can't be read
+func declaredModule(goModPath string) string {
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if name, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.Trim(strings.TrimSpace(name), `"`)
+		}
+	}
+
+	return ""
+}
+
+// warnModuleCollisions prints a warning for each module found by
+// findModuleCollisions, generation goes on regardless
+func warnModuleCollisions(config *ProjectConfig) {
+	for _, goMod := range findModuleCollisions(config.ProjectPath, config.ModulePath) {
+		fmt.Printf(ColorYellow+"⚠ %s already declares module %s, builds and imports may resolve to the wrong module\n"+ColorReset, goMod, config.ModulePath)
+	}
+}
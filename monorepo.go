@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+// generatedGoVersion is the go directive createGoMod writes, a go.work using
+// the project needs at least this version
+const generatedGoVersion = "1.24.1"
+
+// placeholderModule is the module path a project gets when none was given
+func placeholderModule(name string) string {
+	return fmt.Sprintf("github.com/yourusername/%s", name)
+}
+
+// monorepoRoot returns the repository a --monorepo project is generated
+// into: the nearest directory above projectPath with a go.work, or else the
+// nearest one with a .git
+func monorepoRoot(projectPath string) (string, error) {
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return "", err
+	}
+
+	for _, marker := range []string{"go.work", ".git"} {
+		for dir := filepath.Dir(absPath); ; dir = filepath.Dir(dir) {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return dir, nil
+			}
+			if filepath.Dir(dir) == dir {
+				break
+			}
+		}
+	}
+	return "", fmt.Errorf("--monorepo needs an existing repository above %s, no go.work or .git was found", absPath)
+}
+
+// defaultModulePath is the module path of a project none was given for.
+// With --monorepo it follows the project's place in the repository, like
+// github.com/acme/platform/services/orders.
+func (c *ProjectConfig) defaultModulePath() string {
+	if c.Monorepo {
+		if module, err := monorepoModule(c.ProjectPath); err == nil {
+			return module
+		}
+	}
+	return placeholderModule(c.ProjectName)
+}
+
+// monorepoModule derives the module path of projectPath from the module of
+// the repository's root
+func monorepoModule(projectPath string) (string, error) {
+	root, err := monorepoRoot(projectPath)
+	if err != nil {
+		return "", err
+	}
+	rootModule := declaredModule(filepath.Join(root, "go.mod"))
+	if rootModule == "" {
+		return "", fmt.Errorf("%s has no go.mod to derive the module path from, pass --module", root)
+	}
+	use, err := workspaceUse(root, projectPath)
+	if err != nil {
+		return "", err
+	}
+	return rootModule + strings.TrimPrefix(use, "."), nil
+}
+
+// checkMonorepo makes sure a --monorepo project has a repository to join
+// and, without --module, a module path derived from it
+func checkMonorepo(config *ProjectConfig) error {
+	if _, err := monorepoRoot(config.ProjectPath); err != nil {
+		return err
+	}
+	if config.ModulePath == placeholderModule(config.ProjectName) {
+		_, err := monorepoModule(config.ProjectPath)
+		return err
+	}
+	return nil
+}
+
+// workspaceUse is the use path of projectPath in the go.work of root, like
+// ./services/orders
+func workspaceUse(root, projectPath string) (string, error) {
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return "", err
+	}
+	relPath, err := filepath.Rel(root, absPath)
+	if err != nil {
+		return "", err
+	}
+	return "./" + filepath.ToSlash(relPath), nil
+}
+
+// addToGoWork adds the project to the go.work of the repository, creating it
+// with the repository's own module when there is none, so both still build
+func addToGoWork(config *ProjectConfig) error {
+	root, err := monorepoRoot(config.ProjectPath)
+	if err != nil {
+		return err
+	}
+	use, err := workspaceUse(root, config.ProjectPath)
+	if err != nil {
+		return err
+	}
+
+	workPath := filepath.Join(root, "go.work")
+	content, err := os.ReadFile(workPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	work, err := modfile.ParseWork(workPath, content, nil)
+	if err != nil {
+		return err
+	}
+	if len(content) == 0 {
+		if rootModule := declaredModule(filepath.Join(root, "go.mod")); rootModule != "" {
+			if err := work.AddUse(".", rootModule); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The workspace's go version can't be below its modules'
+	goVersion := generatedGoVersion
+	if rootGoMod, err := os.ReadFile(filepath.Join(root, "go.mod")); err == nil {
+		if rootFile, err := modfile.ParseLax("go.mod", rootGoMod, nil); err == nil && rootFile.Go != nil && semver.Compare("v"+rootFile.Go.Version, "v"+goVersion) > 0 {
+			goVersion = rootFile.Go.Version
+		}
+	}
+	if work.Go == nil || semver.Compare("v"+work.Go.Version, "v"+goVersion) < 0 {
+		if err := work.AddGoStmt(goVersion); err != nil {
+			return err
+		}
+	}
+
+	if err := work.AddUse(use, config.ModulePath); err != nil {
+		return err
+	}
+	work.SortBlocks()
+	work.Cleanup()
+	return os.WriteFile(workPath, modfile.Format(work.Syntax), 0644)
+}
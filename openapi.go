@@ -0,0 +1,696 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPIDocument is the part of an OpenAPI 3 document --openapi generates from
+type OpenAPIDocument struct {
+	Paths      map[string]*OpenAPIPathItem `yaml:"paths"`
+	Components struct {
+		Schemas map[string]*OpenAPISchema `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+type OpenAPIPathItem struct {
+	Parameters []*OpenAPIParameter `yaml:"parameters"`
+	Get        *OpenAPIOperation   `yaml:"get"`
+	Post       *OpenAPIOperation   `yaml:"post"`
+	Put        *OpenAPIOperation   `yaml:"put"`
+	Patch      *OpenAPIOperation   `yaml:"patch"`
+	Delete     *OpenAPIOperation   `yaml:"delete"`
+}
+
+type OpenAPIOperation struct {
+	OperationID string                  `yaml:"operationId"`
+	Summary     string                  `yaml:"summary"`
+	Description string                  `yaml:"description"`
+	Tags        []string                `yaml:"tags"`
+	Parameters  []*OpenAPIParameter     `yaml:"parameters"`
+	RequestBody *OpenAPIBody            `yaml:"requestBody"`
+	Responses   map[string]*OpenAPIBody `yaml:"responses"`
+}
+
+type OpenAPIParameter struct {
+	Name     string         `yaml:"name"`
+	In       string         `yaml:"in"`
+	Required bool           `yaml:"required"`
+	Schema   *OpenAPISchema `yaml:"schema"`
+}
+
+// OpenAPIBody is a request body or a response
+type OpenAPIBody struct {
+	Content map[string]struct {
+		Schema *OpenAPISchema `yaml:"schema"`
+	} `yaml:"content"`
+}
+
+type OpenAPISchema struct {
+	Ref        string                    `yaml:"$ref"`
+	Type       string                    `yaml:"type"`
+	Format     string                    `yaml:"format"`
+	Properties map[string]*OpenAPISchema `yaml:"properties"`
+	Required   []string                  `yaml:"required"`
+	Items      *OpenAPISchema            `yaml:"items"`
+}
+
+// jsonSchema returns the schema of the application/json content, if any
+func (b *OpenAPIBody) jsonSchema() *OpenAPISchema {
+	if b == nil {
+		return nil
+	}
+	return b.Content["application/json"].Schema
+}
+
+// openAPIPaths are the files --openapi generates in the project
+var openAPIPaths = []string{
+	"internal/usecase/openapi/entity/openapi.go",
+	"internal/usecase/openapi/openapi_usecase.go",
+	"internal/http/handler/openapi_handler.go",
+}
+
+// openAPIMethods are the supported operations of a path item, in route order
+var openAPIMethods = []string{"get", "post", "put", "patch", "delete"}
+
+func (p *OpenAPIPathItem) operation(method string) *OpenAPIOperation {
+	switch method {
+	case "get":
+		return p.Get
+	case "post":
+		return p.Post
+	case "put":
+		return p.Put
+	case "patch":
+		return p.Patch
+	default:
+		return p.Delete
+	}
+}
+
+// apiStruct is a generated DTO
+type apiStruct struct {
+	Name   string
+	Type   string // set for named non-struct types, like type Pets []Pet
+	Fields []apiField
+}
+
+type apiField struct {
+	Name string
+	Type string
+	Tag  string
+}
+
+// apiOperation is a generated route, handler and usecase method
+type apiOperation struct {
+	Name        string
+	Method      string // Fiber router method, e.g. Get
+	HTTPMethod  string
+	Path        string // OpenAPI path, e.g. /pets/{id}
+	Route       string // Fiber path, e.g. /pets/:id
+	Summary     string
+	Tags        string
+	Params      string // Go type of the path and query params, empty without any
+	PathParams  bool
+	QueryParams bool
+	Body        string // Go type of the JSON request body, empty without one
+	Response    string // Go type of the JSON response, empty without one
+	Status      string
+}
+
+// openAPIGenerator turns a document into DTOs and operations
+type openAPIGenerator struct {
+	doc        *OpenAPIDocument
+	structs    []*apiStruct
+	names      map[string]bool
+	operations []*apiOperation
+}
+
+// loadOpenAPI reads an OpenAPI 3 YAML (or JSON) document
+func loadOpenAPI(path string) (*OpenAPIDocument, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &OpenAPIDocument{}
+	if err := yaml.Unmarshal(content, doc); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document %s: %w", path, err)
+	}
+	if len(doc.Paths) == 0 {
+		return nil, fmt.Errorf("OpenAPI document %s has no paths", path)
+	}
+
+	return doc, nil
+}
+
+// generateOpenAPI writes the handlers, usecase stubs and DTOs of the project's
+// OpenAPI document and registers the handler in cmd/api/main.go
+func generateOpenAPI(config *ProjectConfig) error {
+	if config.Profile == ProfileLite {
+		return fmt.Errorf("the lite profile has no usecase layer to generate into")
+	}
+
+	doc, err := loadOpenAPI(config.OpenAPIFile)
+	if err != nil {
+		return err
+	}
+
+	g := &openAPIGenerator{doc: doc, names: map[string]bool{}}
+	if err := g.build(); err != nil {
+		return err
+	}
+
+	files := map[string]*template.Template{
+		openAPIPaths[0]: openAPIEntityTemplate,
+		openAPIPaths[1]: openAPIUsecaseTemplate,
+		openAPIPaths[2]: openAPIHandlerTemplate,
+	}
+	data := map[string]interface{}{
+		"Module":     config.ModulePath,
+		"Structs":    g.structs,
+		"Operations": g.operations,
+	}
+	for key, used := range g.imports() {
+		data[key] = used
+	}
+	for file, tmpl := range files {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		source, err := format.Source(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+
+		path := filepath.Join(config.ProjectPath, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, source, 0644); err != nil {
+			return err
+		}
+	}
+
+	return registerOpenAPIHandler(config)
+}
+
+// imports reports which packages the generated handler and usecase use, a
+// spec without path params or DTOs shouldn't leave unused imports
+func (g *openAPIGenerator) imports() map[string]bool {
+	imports := map[string]bool{"UsesEntity": false, "UsesAppErr": false, "UsesHTTP": false}
+	for _, op := range g.operations {
+		if op.Params != "" || strings.Contains(qualifiedType(op.Body)+qualifiedType(op.Response), "entity.") {
+			imports["UsesEntity"] = true
+		}
+		if op.PathParams {
+			imports["UsesAppErr"] = true
+		}
+		if strings.HasPrefix(op.Status, "http.") {
+			imports["UsesHTTP"] = true
+		}
+	}
+	return imports
+}
+
+func (g *openAPIGenerator) build() error {
+	schemaNames := make([]string, 0, len(g.doc.Components.Schemas))
+	for name := range g.doc.Components.Schemas {
+		schemaNames = append(schemaNames, name)
+	}
+	sort.Strings(schemaNames)
+	for _, name := range schemaNames {
+		g.names[exportedName(name)] = true
+	}
+	for _, name := range schemaNames {
+		schema := g.doc.Components.Schemas[name]
+		if schema.Type == "object" || len(schema.Properties) > 0 {
+			g.addStruct(exportedName(name), schema)
+		} else {
+			g.structs = append(g.structs, &apiStruct{Name: exportedName(name), Type: g.goType(schema, exportedName(name)+"Item")})
+		}
+	}
+
+	paths := make([]string, 0, len(g.doc.Paths))
+	for path := range g.doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := g.doc.Paths[path]
+		for _, method := range openAPIMethods {
+			operation := item.operation(method)
+			if operation == nil {
+				continue
+			}
+			if err := g.addOperation(path, method, item, operation); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (g *openAPIGenerator) addOperation(path, method string, item *OpenAPIPathItem, operation *OpenAPIOperation) error {
+	name := exportedName(operation.OperationID)
+	if name == "" {
+		name = exportedName(method + " " + path)
+	}
+	for _, existing := range g.operations {
+		if existing.Name == name {
+			return fmt.Errorf("%s %s: operation %s is defined twice", strings.ToUpper(method), path, name)
+		}
+	}
+
+	op := &apiOperation{
+		Name:       name,
+		Method:     exportedName(method),
+		HTTPMethod: method,
+		Path:       path,
+		Route:      fiberRoute(path),
+		Summary:    operation.Summary,
+		Tags:       strings.Join(operation.Tags, ","),
+		Status:     "http.StatusOK",
+	}
+	if op.Summary == "" {
+		op.Summary = name
+	}
+
+	params := &apiStruct{Name: g.uniqueName(name + "Params")}
+	for _, param := range append(append([]*OpenAPIParameter{}, item.Parameters...), operation.Parameters...) {
+		var tag string
+		switch param.In {
+		case "path":
+			tag = fmt.Sprintf(`params:"%s"`, param.Name)
+			op.PathParams = true
+		case "query":
+			tag = fmt.Sprintf(`query:"%s"`, param.Name)
+			op.QueryParams = true
+		default:
+			continue
+		}
+		if param.Required {
+			tag += ` validate:"required"`
+		}
+		params.Fields = append(params.Fields, apiField{
+			Name: exportedName(param.Name),
+			Type: g.goType(param.Schema, params.Name+exportedName(param.Name)),
+			Tag:  tag,
+		})
+	}
+	if len(params.Fields) > 0 {
+		g.structs = append(g.structs, params)
+		g.names[params.Name] = true
+		op.Params = params.Name
+	}
+
+	if schema := operation.RequestBody.jsonSchema(); schema != nil {
+		op.Body = g.goType(schema, name+"Req")
+	}
+
+	codes := make([]string, 0, len(operation.Responses))
+	for code := range operation.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		op.Status = httpStatusConstant(code)
+		if schema := operation.Responses[code].jsonSchema(); schema != nil {
+			op.Response = g.goType(schema, name+"Response")
+		}
+		break
+	}
+
+	g.operations = append(g.operations, op)
+	return nil
+}
+
+// addStruct generates a DTO for an object schema, nested objects get their own
+func (g *openAPIGenerator) addStruct(name string, schema *OpenAPISchema) {
+	s := &apiStruct{Name: name}
+	g.names[name] = true
+	g.structs = append(g.structs, s)
+
+	properties := make([]string, 0, len(schema.Properties))
+	for property := range schema.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	for _, property := range properties {
+		tag := fmt.Sprintf(`json:"%s,omitempty"`, property)
+		if contains(schema.Required, property) {
+			tag = fmt.Sprintf(`json:"%s" validate:"required"`, property)
+		}
+		s.Fields = append(s.Fields, apiField{
+			Name: exportedName(property),
+			Type: g.goType(schema.Properties[property], name+exportedName(property)),
+			Tag:  tag,
+		})
+	}
+}
+
+// goType is the Go type of schema, inline objects become structs named hint
+func (g *openAPIGenerator) goType(schema *OpenAPISchema, hint string) string {
+	if schema == nil {
+		return "interface{}"
+	}
+	if schema.Ref != "" {
+		return exportedName(schema.Ref[strings.LastIndex(schema.Ref, "/")+1:])
+	}
+
+	switch schema.Type {
+	case "string":
+		return "string"
+	case "integer":
+		if schema.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		if schema.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + g.goType(schema.Items, hint+"Item")
+	case "object", "":
+		if len(schema.Properties) == 0 {
+			if schema.Type == "" {
+				return "interface{}"
+			}
+			return "map[string]interface{}"
+		}
+		name := g.uniqueName(hint)
+		g.addStruct(name, schema)
+		return name
+	default:
+		return "interface{}"
+	}
+}
+
+// uniqueName suffixes name with a number when a DTO already has it
+func (g *openAPIGenerator) uniqueName(name string) string {
+	unique := name
+	for i := 2; g.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	return unique
+}
+
+// openAPIInitialisms are kept upper case in generated names, like golint wants
+var openAPIInitialisms = map[string]bool{"id": true, "url": true, "uri": true, "api": true, "http": true, "json": true, "uuid": true, "ip": true, "sql": true}
+
+// exportedName turns an OpenAPI name like pet_id, petId or get /pets/{id} into PetID
+func exportedName(name string) string {
+	words := []string{}
+	word := []rune{}
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = []rune{}
+		}
+	}
+
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+
+	var b strings.Builder
+	for _, w := range words {
+		lower := strings.ToLower(w)
+		if openAPIInitialisms[lower] {
+			b.WriteString(strings.ToUpper(lower))
+			continue
+		}
+		b.WriteString(strings.ToUpper(lower[:1]) + lower[1:])
+	}
+
+	result := b.String()
+	if result != "" && unicode.IsDigit([]rune(result)[0]) {
+		result = "N" + result
+	}
+	return result
+}
+
+// fiberRoute turns /pets/{petId} into /pets/:petId
+func fiberRoute(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = ":" + segment[1:len(segment)-1]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+var httpStatusConstants = map[string]string{
+	"200": "http.StatusOK",
+	"201": "http.StatusCreated",
+	"202": "http.StatusAccepted",
+	"204": "http.StatusNoContent",
+}
+
+func httpStatusConstant(code string) string {
+	if constant, ok := httpStatusConstants[code]; ok {
+		return constant
+	}
+	if code == "2XX" || code == "2xx" {
+		return "http.StatusOK"
+	}
+	return code
+}
+
+// qualifiedType prefixes the DTOs of a Go type with their package, []Pet becomes []entity.Pet
+func qualifiedType(goType string) string {
+	prefix := ""
+	for {
+		switch {
+		case strings.HasPrefix(goType, "[]"):
+			prefix += "[]"
+			goType = goType[2:]
+			continue
+		case strings.HasPrefix(goType, "map[string]"):
+			prefix += "map[string]"
+			goType = goType[len("map[string]"):]
+			continue
+		}
+		break
+	}
+	if goType != "" && unicode.IsUpper([]rune(goType)[0]) {
+		return prefix + "entity." + goType
+	}
+	return prefix + goType
+}
+
+// resultType is what a usecase method returns for a response, structs by pointer
+func resultType(goType string) string {
+	qualified := qualifiedType(goType)
+	if strings.HasPrefix(qualified, "entity.") {
+		return "*" + qualified
+	}
+	return qualified
+}
+
+// openAPIWiring registers the generated handler, the keys are the lines of
+// cmd/api/main.go it goes after
+var openAPIWiring = map[string]string{
+	`"%s/internal/http/handler"`:  `	openapi_usecase "%s/internal/usecase/openapi"`,
+	`api := app.Group("/api/v1")`: "\n\t// Generated from the OpenAPI spec, the business logic goes in openapi_usecase.OpenAPIUsecase\n\thandler.NewOpenAPIHandler(parser, presenterJson, openapi_usecase.NewOpenAPIUsecase()).Register(api)",
+}
+
+func registerOpenAPIHandler(config *ProjectConfig) error {
+	path := filepath.Join(config.ProjectPath, "cmd/api/main.go")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	for anchor, addition := range openAPIWiring {
+		anchor = strings.ReplaceAll(anchor, "%s", config.ModulePath)
+		addition = strings.ReplaceAll(addition, "%s", config.ModulePath)
+
+		found := false
+		for i, line := range lines {
+			if strings.TrimSpace(line) == anchor {
+				lines = append(lines[:i+1], append([]string{addition}, lines[i+1:]...)...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("cmd/api/main.go: %s not found, register the OpenAPI handler by hand", anchor)
+		}
+	}
+
+	// gofmt puts the new import in order
+	source, err := format.Source([]byte(strings.Join(lines, "\n")))
+	if err != nil {
+		return fmt.Errorf("cmd/api/main.go: %w", err)
+	}
+
+	return os.WriteFile(path, source, 0644)
+}
+
+var openAPIFuncs = template.FuncMap{
+	"qualified": qualifiedType,
+	"result":    resultType,
+	"lower":     strings.ToLower,
+}
+
+var openAPIEntityTemplate = template.Must(template.New("entity").Parse(`// Code generated from the OpenAPI spec by go-skeleton, edit freely.
+
+package entity
+{{range .Structs}}
+{{if .Type}}type {{.Name}} {{.Type}}{{else}}type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`{{.Tag}}`" + `
+{{- end}}
+}{{end}}
+{{end}}`))
+
+var openAPIUsecaseTemplate = template.Must(template.New("usecase").Funcs(openAPIFuncs).Parse(`// Code generated from the OpenAPI spec by go-skeleton, edit freely.
+
+package openapi_usecase
+
+import (
+	"context"
+	"net/http"
+
+	generalEntity "{{.Module}}/entity"
+	apperr "{{.Module}}/error"
+{{- if .UsesEntity}}
+	"{{.Module}}/internal/usecase/openapi/entity"
+{{- end}}
+)
+
+// IOpenAPIUsecase has a method per operation of the OpenAPI spec
+type IOpenAPIUsecase interface {
+{{- range .Operations}}
+	{{template "signature" .}}
+{{- end}}
+}
+
+// OpenAPIUsecase implements the operations, every method answers 501 until
+// its business logic is written
+type OpenAPIUsecase struct{}
+
+func NewOpenAPIUsecase() *OpenAPIUsecase {
+	return &OpenAPIUsecase{}
+}
+{{range .Operations}}
+// {{.Name}} handles {{.HTTPMethod}} {{.Path}}
+func (u *OpenAPIUsecase) {{template "signature" .}} {
+	return {{if .Response}}nil, {{end}}errNotImplemented()
+}
+{{end}}
+func errNotImplemented() error {
+	return apperr.CustomError("Not implemented", generalEntity.BAD_REQUEST_CODE, http.StatusNotImplemented)
+}
+{{define "signature"}}{{.Name}}(ctx context.Context{{if .Params}}, params entity.{{.Params}}{{end}}{{if .Body}}, req {{qualified .Body}}{{end}}) {{if .Response}}({{result .Response}}, error){{else}}error{{end}}{{end}}
+`))
+
+var openAPIHandlerTemplate = template.Must(template.New("handler").Funcs(openAPIFuncs).Parse(`// Code generated from the OpenAPI spec by go-skeleton, edit freely.
+
+package handler
+
+import (
+{{- if .UsesHTTP}}
+	"net/http"
+{{end}}
+{{- if .UsesAppErr}}
+	apperr "{{.Module}}/error"
+{{- end}}
+	"{{.Module}}/internal/parser"
+	"{{.Module}}/internal/presenter/json"
+	openapi_usecase "{{.Module}}/internal/usecase/openapi"
+{{- if .UsesEntity}}
+	"{{.Module}}/internal/usecase/openapi/entity"
+{{- end}}
+
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+type OpenAPIHandler struct {
+	parser         parser.Parser
+	presenter      json.JsonPresenter
+	openapiUsecase openapi_usecase.IOpenAPIUsecase
+}
+
+func NewOpenAPIHandler(
+	parser parser.Parser,
+	presenter json.JsonPresenter,
+	openapiUsecase openapi_usecase.IOpenAPIUsecase,
+) *OpenAPIHandler {
+	return &OpenAPIHandler{parser, presenter, openapiUsecase}
+}
+
+func (w *OpenAPIHandler) Register(app fiber.Router) {
+{{- range .Operations}}
+	app.{{.Method}}("{{.Route}}", w.{{.Name}})
+{{- end}}
+}
+{{range .Operations}}
+// @Summary			{{.Summary}}
+{{- if .Tags}}
+// @Tags			{{.Tags}}
+{{- end}}
+// @Accept			json
+// @Produce			json
+// @Router			/api/v1{{.Path}} [{{.HTTPMethod}}]
+func (w *OpenAPIHandler) {{.Name}}(c *fiber.Ctx) error {
+{{- if .Params}}
+	var params entity.{{.Params}}
+{{- if .PathParams}}
+	if err := c.ParamsParser(&params); err != nil {
+		return w.presenter.BuildError(c, apperr.ErrInvalidRequest())
+	}
+{{- end}}
+{{- if .QueryParams}}
+	if err := w.parser.ParseQueryParams(c, &params); err != nil {
+		return w.presenter.BuildError(c, err)
+	}
+{{- end}}
+{{end}}
+{{- if .Body}}
+	var req {{qualified .Body}}
+	if err := w.parser.ParserBodyRequest(c, &req); err != nil {
+		return w.presenter.BuildError(c, err)
+	}
+{{end}}
+	{{if .Response}}data, err{{else}}err{{end}} := w.openapiUsecase.{{.Name}}(c.Context(){{if .Params}}, params{{end}}{{if .Body}}, req{{end}})
+	if err != nil {
+		return w.presenter.BuildError(c, err)
+	}
+
+	return w.presenter.BuildSuccess(c, {{if .Response}}data{{else}}nil{{end}}, "Success", {{.Status}})
+}
+{{end}}`))
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// postmanDir holds the collection written with --postman
+const postmanDir = "api-client/postman"
+
+// postmanSchema is the collection format Postman and Insomnia import
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// postmanMethods maps the Fiber router methods to their HTTP method, All is
+// only used by GraphQL, which takes POST requests
+var postmanMethods = map[string]string{
+	"Get":    "GET",
+	"Post":   "POST",
+	"Put":    "PUT",
+	"Patch":  "PATCH",
+	"Delete": "DELETE",
+	"All":    "POST",
+}
+
+// apiRoute is a route registered by the generated API
+type apiRoute struct {
+	Folder string // handler registering the route, "" for the routes of main.go
+	Method string
+	Path   string
+	Guard  string // middleware authenticating the route, "" when it is public
+}
+
+type postmanCollection struct {
+	Info     postmanInfo       `json:"info"`
+	Item     []postmanItem     `json:"item"`
+	Variable []postmanVariable `json:"variable"`
+}
+
+type postmanInfo struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+// postmanItem is a folder when it has items, a request otherwise
+type postmanItem struct {
+	Name    string          `json:"name"`
+	Item    []postmanItem   `json:"item,omitempty"`
+	Request *postmanRequest `json:"request,omitempty"`
+}
+
+type postmanRequest struct {
+	Method string            `json:"method"`
+	Header []postmanVariable `json:"header"`
+	Auth   postmanAuth       `json:"auth"`
+	Body   *postmanBody      `json:"body,omitempty"`
+	URL    postmanURL        `json:"url"`
+}
+
+type postmanAuth struct {
+	Type   string            `json:"type"`
+	Bearer []postmanVariable `json:"bearer,omitempty"`
+	Apikey []postmanVariable `json:"apikey,omitempty"`
+}
+
+type postmanBody struct {
+	Mode    string                    `json:"mode"`
+	Raw     string                    `json:"raw"`
+	Options map[string]map[string]any `json:"options"`
+}
+
+type postmanURL struct {
+	Raw      string            `json:"raw"`
+	Host     []string          `json:"host"`
+	Path     []string          `json:"path"`
+	Variable []postmanVariable `json:"variable,omitempty"`
+}
+
+type postmanVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// generatePostmanCollection writes the API routes of the generated project as
+// a Postman collection, pointing at the chosen API port
+func generatePostmanCollection(config *ProjectConfig) error {
+	routes, err := collectRoutes(config.ProjectPath)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(postmanCollectionOf(config, routes), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(config.ProjectPath, postmanDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, config.ProjectName+".postman_collection.json"), append(content, '\n'), 0644)
+}
+
+// postmanCollectionOf groups routes in a folder per handler, the routes of
+// main.go stay outside of any folder, in the order they are registered
+func postmanCollectionOf(config *ProjectConfig, routes []apiRoute) *postmanCollection {
+	collection := &postmanCollection{
+		Info:     postmanInfo{Name: config.ProjectName, Schema: postmanSchema},
+		Item:     []postmanItem{},
+		Variable: []postmanVariable{{Key: "baseUrl", Value: fmt.Sprintf("http://localhost:%d", config.ApiPort)}},
+	}
+
+	folders := map[string]int{}
+	guards := map[string]bool{}
+	for _, route := range routes {
+		guards[route.Guard] = true
+		item := postmanItem{Name: route.Method + " " + route.Path, Request: postmanRequestOf(route)}
+
+		if route.Folder == "" {
+			collection.Item = append(collection.Item, item)
+			continue
+		}
+		index, ok := folders[route.Folder]
+		if !ok {
+			index = len(collection.Item)
+			folders[route.Folder] = index
+			collection.Item = append(collection.Item, postmanItem{Name: route.Folder})
+		}
+		collection.Item[index].Item = append(collection.Item[index].Item, item)
+	}
+
+	if guards["VerifyJWTToken"] || guards["VerifyOidcToken"] {
+		collection.Variable = append(collection.Variable, postmanVariable{Key: "token", Value: ""})
+	}
+	if guards["VerifyApiKey"] {
+		collection.Variable = append(collection.Variable, postmanVariable{Key: "apiKey", Value: ""})
+	}
+
+	return collection
+}
+
+func postmanRequestOf(route apiRoute) *postmanRequest {
+	request := &postmanRequest{
+		Method: route.Method,
+		Header: []postmanVariable{},
+		Auth:   postmanAuth{Type: "noauth"},
+		URL:    postmanURL{Raw: "{{baseUrl}}" + route.Path, Host: []string{"{{baseUrl}}"}, Path: []string{}},
+	}
+
+	for _, segment := range strings.Split(strings.Trim(route.Path, "/"), "/") {
+		request.URL.Path = append(request.URL.Path, segment)
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			request.URL.Variable = append(request.URL.Variable, postmanVariable{Key: name, Value: ""})
+		}
+	}
+
+	switch route.Guard {
+	case "VerifyJWTToken", "VerifyOidcToken":
+		request.Auth = postmanAuth{Type: "bearer", Bearer: []postmanVariable{{Key: "token", Value: "{{token}}", Type: "string"}}}
+	case "VerifyApiKey":
+		request.Auth = postmanAuth{Type: "apikey", Apikey: []postmanVariable{
+			{Key: "key", Value: "X-API-Key", Type: "string"},
+			{Key: "value", Value: "{{apiKey}}", Type: "string"},
+			{Key: "in", Value: "header", Type: "string"},
+		}}
+	}
+
+	if route.Method == "POST" || route.Method == "PUT" || route.Method == "PATCH" {
+		request.Header = append(request.Header, postmanVariable{Key: "Content-Type", Value: "application/json"})
+		request.Body = &postmanBody{
+			Mode:    "raw",
+			Raw:     "{}",
+			Options: map[string]map[string]any{"raw": {"language": "json"}},
+		}
+	}
+
+	return request
+}
+
+// collectRoutes reads the routes from the API's main.go: the ones it registers
+// itself and those of the handlers it calls Register on, prefixed with the
+// group they are registered on
+func collectRoutes(projectPath string) ([]apiRoute, error) {
+	handlers, err := handlerRoutes(filepath.Join(projectPath, "internal/http/handler"))
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(projectPath, "cmd/api/main.go"), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Router variables and their path prefix
+	prefixes := map[string]string{"app": ""}
+	routes := []apiRoute{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) != 1 || len(node.Rhs) != 1 {
+				return true
+			}
+			name, ok := node.Lhs[0].(*ast.Ident)
+			router, method, path, _, isCall := routerCall(node.Rhs[0], prefixes)
+			if ok && isCall && method == "Group" {
+				prefixes[name.Name] = prefixes[router] + path
+			}
+		case *ast.CallExpr:
+			if handler, router, ok := registerCall(node, prefixes); ok {
+				for _, route := range handlers[handler] {
+					route.Path = prefixes[router] + route.Path
+					routes = append(routes, route)
+				}
+				return true
+			}
+			router, method, path, guard, ok := routerCall(node, prefixes)
+			if httpMethod, isRoute := postmanMethods[method]; ok && isRoute && !strings.Contains(path, "*") {
+				routes = append(routes, apiRoute{Method: httpMethod, Path: prefixes[router] + path, Guard: guard})
+			}
+		}
+		return true
+	})
+
+	return routes, nil
+}
+
+// handlerRoutes reads the routes each handler type adds in its Register method
+func handlerRoutes(dir string) (map[string][]apiRoute, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	handlers := map[string][]apiRoute{}
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Name.Name != "Register" || fn.Recv == nil || len(fn.Type.Params.List) != 1 || len(fn.Type.Params.List[0].Names) != 1 {
+				continue
+			}
+			handler := receiverType(fn.Recv.List[0].Type)
+			routers := map[string]string{fn.Type.Params.List[0].Names[0].Name: ""}
+
+			ast.Inspect(fn.Body, func(node ast.Node) bool {
+				_, method, path, guard, ok := routerCall(node, routers)
+				if httpMethod, isRoute := postmanMethods[method]; ok && isRoute && !strings.Contains(path, "*") {
+					handlers[handler] = append(handlers[handler], apiRoute{Folder: folderName(handler), Method: httpMethod, Path: path, Guard: guard})
+				}
+				return true
+			})
+		}
+	}
+
+	return handlers, nil
+}
+
+// routerCall matches router.Method("/path", handlers...) on one of routers,
+// guard is the middleware.Verify* handler among the arguments
+func routerCall(node ast.Node, routers map[string]string) (router, method, path, guard string, ok bool) {
+	call, isCall := node.(*ast.CallExpr)
+	if !isCall || len(call.Args) == 0 {
+		return "", "", "", "", false
+	}
+	selector, isSelector := call.Fun.(*ast.SelectorExpr)
+	if !isSelector {
+		return "", "", "", "", false
+	}
+	receiver, isIdent := selector.X.(*ast.Ident)
+	if !isIdent {
+		return "", "", "", "", false
+	}
+	if _, known := routers[receiver.Name]; !known {
+		return "", "", "", "", false
+	}
+	literal, isLiteral := call.Args[0].(*ast.BasicLit)
+	if !isLiteral || literal.Kind != token.STRING {
+		return "", "", "", "", false
+	}
+	path, err := strconv.Unquote(literal.Value)
+	if err != nil {
+		return "", "", "", "", false
+	}
+
+	for _, arg := range call.Args[1:] {
+		if handler, isSelector := arg.(*ast.SelectorExpr); isSelector && strings.HasPrefix(handler.Sel.Name, "Verify") {
+			if pkg, isIdent := handler.X.(*ast.Ident); isIdent && pkg.Name == "middleware" {
+				guard = handler.Sel.Name
+			}
+		}
+	}
+
+	return receiver.Name, selector.Sel.Name, path, guard, true
+}
+
+// registerCall matches handler.NewXHandler(...).Register(router) and returns
+// the handler type and the router
+func registerCall(call *ast.CallExpr, routers map[string]string) (handler, router string, ok bool) {
+	selector, isSelector := call.Fun.(*ast.SelectorExpr)
+	if !isSelector || selector.Sel.Name != "Register" || len(call.Args) != 1 {
+		return "", "", false
+	}
+	arg, isIdent := call.Args[0].(*ast.Ident)
+	if !isIdent {
+		return "", "", false
+	}
+	if _, known := routers[arg.Name]; !known {
+		return "", "", false
+	}
+	constructor, isCall := selector.X.(*ast.CallExpr)
+	if !isCall {
+		return "", "", false
+	}
+	name, isSelector := constructor.Fun.(*ast.SelectorExpr)
+	if !isSelector || !strings.HasPrefix(name.Sel.Name, "New") {
+		return "", "", false
+	}
+
+	return strings.TrimPrefix(name.Sel.Name, "New"), arg.Name, true
+}
+
+// receiverType returns the type name of a method receiver, without the pointer
+func receiverType(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// folderName turns a handler type into its folder, TodoListHandler is "Todo List"
+func folderName(handler string) string {
+	name := strings.TrimSuffix(handler, "Handler")
+
+	var words strings.Builder
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) && !unicode.IsUpper(rune(name[i-1])) {
+			words.WriteByte(' ')
+		}
+		words.WriteRune(r)
+	}
+	return words.String()
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// Formats of --print-config
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+var supportedOutputs = []string{OutputText, OutputJSON}
+
+// printConfig writes the resolved config, as "Field: value" lines or as the
+// JSON the generation log records it with
+func printConfig(w io.Writer, config *ProjectConfig, format string) error {
+	if format == OutputJSON {
+		content, err := json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(content))
+		return err
+	}
+
+	value := reflect.ValueOf(config).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		line := fmt.Sprintf("%-17s %v", value.Type().Field(i).Name+":", value.Field(i).Interface())
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
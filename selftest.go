@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var supportedDatabases = []string{"mysql", "mariadb", "postgresql", "cockroachdb", "mongodb"}
+
+// SelfTestResult is the outcome of generating and building one configuration
+type SelfTestResult struct {
+	Config *ProjectConfig
+	Passed bool
+	Output string
+}
+
+// selfTestAuths and selfTestLogStores are the auth and log store dimensions of
+// the self-test, the other auth modes only swap the middleware of JWT
+var (
+	selfTestAuths     = []string{AuthJWT, AuthNone}
+	selfTestLogStores = []string{LogStoreNone, LogStoreSQL}
+)
+
+// selfTestExtras are built once each on top of the matrix, for the options
+// whose wiring doesn't depend on the database or services
+var selfTestExtras = []struct {
+	suffix string
+	config ProjectConfig
+}{
+	{"auth_apikey", ProjectConfig{Database: "mysql", LogStore: LogStoreNone, Auth: AuthApiKey}},
+	{"auth_oidc", ProjectConfig{Database: "mysql", LogStore: LogStoreNone, Auth: AuthOidc}},
+	{"profile_lite", ProjectConfig{Profile: ProfileLite, Auth: AuthJWT}},
+	{"logs_mongo", ProjectConfig{Database: "mysql", LogStore: LogStoreMongo, Auth: AuthJWT, UseRabbitMQ: true}},
+	{"mongodb-logs_mongo", ProjectConfig{Database: "mongodb", LogStore: LogStoreMongo, Auth: AuthJWT, UseRabbitMQ: true}},
+}
+
+// selfTestConfigurations returns every database × service × auth × log store
+// combination the generator can produce, the ones validateConfig rejects, like
+// the sql log store without RabbitMQ, are left out. selfTestExtras follow.
+func selfTestConfigurations(baseDir string) []*ProjectConfig {
+	configs := []*ProjectConfig{}
+
+	for _, db := range supportedDatabases {
+		for _, useRedis := range []bool{false, true} {
+			for _, useRabbitMQ := range []bool{false, true} {
+				for _, auth := range selfTestAuths {
+					for _, logStore := range selfTestLogStores {
+						name := strings.ToLower(fmt.Sprintf("selftest-%s-redis_%s-rabbitmq_%s-auth_%s-logs_%s", db, boolToYesNo(useRedis), boolToYesNo(useRabbitMQ), auth, logStore))
+						config := &ProjectConfig{
+							ProjectName: name,
+							ProjectPath: filepath.Join(baseDir, name),
+							ModulePath:  "example.com/selftest/" + name,
+							Database:    db,
+							LogStore:    logStore,
+							Auth:        auth,
+							UseRedis:    useRedis,
+							UseRabbitMQ: useRabbitMQ,
+						}
+						if validateConfig(config) != nil {
+							continue
+						}
+						configs = append(configs, config)
+					}
+				}
+			}
+		}
+	}
+
+	for _, extra := range selfTestExtras {
+		name := "selftest-" + extra.suffix
+		config := extra.config
+		config.ProjectName = name
+		config.ProjectPath = filepath.Join(baseDir, name)
+		config.ModulePath = "example.com/selftest/" + name
+		config.applyProfile()
+		configs = append(configs, &config)
+	}
+
+	return configs
+}
+
+// runSelfTest generates every combination into a temp dir, runs `go build ./...`
+// and `go vet ./...` on each and prints a pass/fail matrix. Each configuration is cancelled after
+// timeout, unless it's 0. It returns false if any build failed.
+func runSelfTest(offline bool, timeout time.Duration) bool {
+	fmt.Println(ColorBlue + "🧪 Running template self-test..." + ColorReset)
+
+	baseDir, err := os.MkdirTemp("", "go-skeleton-selftest-")
+	if err != nil {
+		fmt.Printf(ColorYellow+"Error: %v\n"+ColorReset, err)
+		return false
+	}
+	defer os.RemoveAll(baseDir)
+
+	results := []SelfTestResult{}
+	for _, config := range selfTestConfigurations(baseDir) {
+		config.Timeout = timeout
+		results = append(results, selfTestConfiguration(config, offline))
+	}
+
+	printSelfTestMatrix(results)
+
+	for _, result := range results {
+		if !result.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+func selfTestConfiguration(config *ProjectConfig, offline bool) SelfTestResult {
+	result := SelfTestResult{Config: config}
+
+	err := createAndCheckProject(config, func(ctx context.Context) error {
+		output, err := buildProject(ctx, config.ProjectPath, offline)
+		result.Output = output
+		if err != nil {
+			return err
+		}
+
+		output, err = vetProject(ctx, config.ProjectPath, offline)
+		result.Output = output
+		return err
+	})
+	if err != nil {
+		result.Output = strings.TrimSpace(result.Output + "\n" + err.Error())
+		return result
+	}
+
+	result.Passed = true
+	return result
+}
+
+func printSelfTestMatrix(results []SelfTestResult) {
+	fmt.Println()
+	fmt.Println(ColorBlue + "📋 Self-test results:" + ColorReset)
+	fmt.Printf("  %-12s %-6s %-9s %-7s %-9s %s\n", "Database", "Redis", "RabbitMQ", "Auth", "LogStore", "Result")
+
+	failed := 0
+	for _, result := range results {
+		status := ColorGreen + "PASS" + ColorReset
+		if !result.Passed {
+			status = ColorYellow + "FAIL" + ColorReset
+			failed++
+		}
+
+		fmt.Printf("  %-12s %-6s %-9s %-7s %-9s %s\n",
+			result.Config.Database,
+			boolToYesNo(result.Config.UseRedis),
+			boolToYesNo(result.Config.UseRabbitMQ),
+			result.Config.Auth,
+			result.Config.LogStore,
+			status)
+	}
+
+	for _, result := range results {
+		if result.Passed {
+			continue
+		}
+
+		fmt.Println()
+		fmt.Println(ColorYellow + "✗ " + result.Config.ProjectName + ColorReset)
+		fmt.Println(strings.TrimSpace(result.Output))
+	}
+
+	fmt.Println()
+	fmt.Printf("%d/%d configurations passed\n", len(results)-failed, len(results))
+}
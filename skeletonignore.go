@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// skeletonIgnoreFile lists, in the template root, the files never copied to
+// the projects, like maintainer scripts. It takes gitignore-style patterns and
+// isn't copied itself.
+const skeletonIgnoreFile = ".skeletonignore"
+
+// ignorePattern is a line of skeletonIgnoreFile
+type ignorePattern struct {
+	segments []string // the pattern split on /, ** matching any number of directories
+	negate   bool     // the pattern started with !, it keeps what an earlier one ignored
+	dirOnly  bool     // the pattern ended with /, it only matches directories
+}
+
+// ignoreRules are the patterns of a skeletonIgnoreFile, the last matching one wins
+type ignoreRules []ignorePattern
+
+// loadIgnoreRules reads the skeletonIgnoreFile of root in source, a missing
+// file ignores nothing
+func loadIgnoreRules(source fs.FS, root string) (ignoreRules, error) {
+	content, err := fs.ReadFile(source, path.Join(root, skeletonIgnoreFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rules := ignoreRules{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var pattern ignorePattern
+		line, pattern.negate = strings.CutPrefix(line, "!")
+		line, pattern.dirOnly = strings.CutSuffix(line, "/")
+		// A pattern without a slash matches at any depth, one with a slash
+		// is relative to the template root
+		if !strings.Contains(line, "/") {
+			line = "**/" + line
+		}
+		pattern.segments = strings.Split(strings.TrimPrefix(line, "/"), "/")
+
+		for _, segment := range pattern.segments {
+			if _, err := path.Match(segment, ""); err != nil {
+				return nil, fmt.Errorf("%s:%d: bad pattern %q: %w", skeletonIgnoreFile, lineNumber, scanner.Text(), err)
+			}
+		}
+		rules = append(rules, pattern)
+	}
+	return rules, scanner.Err()
+}
+
+// ignored reports whether relPath, relative to the template root, is left out
+// of the projects. Everything under an ignored directory is too, as the walk
+// skips it.
+func (r ignoreRules) ignored(relPath string, isDir bool) bool {
+	if relPath == skeletonIgnoreFile {
+		return true
+	}
+
+	ignored := false
+	segments := strings.Split(relPath, "/")
+	for _, pattern := range r {
+		if pattern.dirOnly && !isDir {
+			continue
+		}
+		if matchSegments(pattern.segments, segments) {
+			ignored = !pattern.negate
+		}
+	}
+	return ignored
+}
+
+// matchSegments matches the path segments against the pattern ones, ** standing
+// for zero or more segments
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	matched, _ := path.Match(pattern[0], segments[0])
+	return matched && matchSegments(pattern[1:], segments[1:])
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// specSchemaJSON is the published schema of the spec file, validation is driven by it
+//
+//go:embed spec.schema.json
+var specSchemaJSON []byte
+
+// SpecSchema is the subset of JSON Schema used by spec.schema.json
+type SpecSchema struct {
+	Required   []string                      `json:"required"`
+	Properties map[string]SpecSchemaProperty `json:"properties"`
+}
+
+type SpecSchemaProperty struct {
+	Type    string   `json:"type"`
+	Enum    []string `json:"enum"`
+	Minimum *int     `json:"minimum"`
+	Maximum *int     `json:"maximum"`
+}
+
+// ProjectSpec is a declarative project description loaded with --config
+type ProjectSpec struct {
+	Name         string `json:"name"`
+	Module       string `json:"module"`
+	Profile      string `json:"profile"`
+	Database     string `json:"database"`
+	LogStore     string `json:"logStore"`
+	Auth         string `json:"auth"`
+	UseRedis     bool   `json:"useRedis"`
+	UseRabbitMQ  bool   `json:"useRabbitMQ"`
+	ApiPort      int    `json:"apiPort"`
+	DocPort      int    `json:"docPort"`
+	DBPort       int    `json:"dbPort"`
+	RedisPort    int    `json:"redisPort"`
+	RabbitMQPort int    `json:"rabbitmqPort"`
+	MongoPort    int    `json:"mongoPort"`
+}
+
+func loadSpecSchema() (*SpecSchema, error) {
+	schema := &SpecSchema{}
+	if err := json.Unmarshal(specSchemaJSON, schema); err != nil {
+		return nil, fmt.Errorf("invalid spec schema: %w", err)
+	}
+	return schema, nil
+}
+
+// loadSpec reads a spec file and validates it against the schema
+func loadSpec(path string) (*ProjectSpec, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseSpec(path, string(content))
+}
+
+// parseSpec parses the flat `key: value` YAML of a spec file. Every problem is
+// reported with its line, all at once, so a spec can be fixed in one go.
+func parseSpec(path, content string) (*ProjectSpec, error) {
+	schema, err := loadSpecSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]any{}
+	errs := []error{}
+
+	for i, line := range strings.Split(content, "\n") {
+		lineNo := i + 1
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+
+		key, raw, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s:%d: expected `field: value`, got %q", path, lineNo, trimmed))
+			continue
+		}
+		key = strings.TrimSpace(key)
+		raw = unquoteSpecValue(raw)
+
+		property, known := schema.Properties[key]
+		if !known {
+			errs = append(errs, fmt.Errorf("%s:%d: unknown field %q%s", path, lineNo, key, suggestField(schema, key)))
+			continue
+		}
+		if _, seen := values[key]; seen {
+			errs = append(errs, fmt.Errorf("%s:%d: field %q is set more than once", path, lineNo, key))
+			continue
+		}
+
+		value, err := property.parse(raw)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s:%d: field %q: %w", path, lineNo, key, err))
+			continue
+		}
+		values[key] = value
+	}
+
+	for _, key := range schema.Required {
+		if _, ok := values[key]; !ok {
+			errs = append(errs, fmt.Errorf("%s: missing required field %q", path, key))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// The values are checked against the schema, let encoding/json map them on the struct
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	spec := &ProjectSpec{}
+	if err := json.Unmarshal(encoded, spec); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+// parse converts a raw YAML scalar to the property's type and checks its constraints
+func (p SpecSchemaProperty) parse(raw string) (any, error) {
+	switch p.Type {
+	case "boolean":
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("expected a boolean (true or false), got %q", raw)
+		}
+		return value, nil
+	case "integer":
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer, got %q", raw)
+		}
+		if p.Minimum != nil && value < *p.Minimum {
+			return nil, fmt.Errorf("%d is below the minimum of %d", value, *p.Minimum)
+		}
+		if p.Maximum != nil && value > *p.Maximum {
+			return nil, fmt.Errorf("%d is above the maximum of %d", value, *p.Maximum)
+		}
+		return value, nil
+	default:
+		if raw == "" {
+			return nil, fmt.Errorf("expected a value")
+		}
+		if len(p.Enum) > 0 && !contains(p.Enum, raw) {
+			return nil, fmt.Errorf("%q is not allowed, expected one of: %s", raw, strings.Join(p.Enum, ", "))
+		}
+		return raw, nil
+	}
+}
+
+func unquoteSpecValue(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if len(raw) >= 2 && (raw[0] == '"' || raw[0] == '\'') && raw[len(raw)-1] == raw[0] {
+		return raw[1 : len(raw)-1]
+	}
+	// Drop trailing comments from unquoted values
+	if i := strings.Index(raw, " #"); i >= 0 {
+		raw = strings.TrimSpace(raw[:i])
+	}
+	return raw
+}
+
+// suggestField points to the closest known field for typos like `databse`
+func suggestField(schema *SpecSchema, key string) string {
+	fields := []string{}
+	for field := range schema.Properties {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	best, bestDistance := "", 3
+	for _, field := range fields {
+		if d := editDistance(strings.ToLower(key), strings.ToLower(field)); d < bestDistance {
+			best, bestDistance = field, d
+		}
+	}
+	if best == "" {
+		return ", allowed fields: " + strings.Join(fields, ", ")
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+
+	return previous[len(b)]
+}
+
+// specConfiguration builds the project config from a spec, unset fields take
+// the same defaults as --interactive=false
+func specConfiguration(spec *ProjectSpec, opts *CLIOptions) *ProjectConfig {
+	config := &ProjectConfig{
+		ProjectName:  spec.Name,
+		ProjectPath:  "./" + spec.Name,
+		ModulePath:   spec.Module,
+		Database:     "mysql",
+		Profile:      ProfileFull,
+		LogStore:     LogStoreNone,
+		UseRedis:     spec.UseRedis,
+		UseRabbitMQ:  spec.UseRabbitMQ,
+		ApiPort:      spec.ApiPort,
+		ApiDocPort:   spec.DocPort,
+		DBPort:       spec.DBPort,
+		RedisPort:    spec.RedisPort,
+		RabbitMQPort: spec.RabbitMQPort,
+		MongoPort:    spec.MongoPort,
+	}
+	config.applyCLIOptions(opts)
+
+	if spec.Database != "" {
+		config.Database = spec.Database
+	}
+	if spec.Profile != "" {
+		config.Profile = spec.Profile
+	}
+	if spec.LogStore != "" {
+		config.LogStore = spec.LogStore
+	}
+	if spec.Auth != "" {
+		config.Auth = spec.Auth
+	}
+	if config.ModulePath == "" {
+		config.ModulePath = config.defaultModulePath()
+	}
+
+	config.applyProfile()
+	config.applyPortDefaults()
+
+	return config
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gofiber/swagger"
+	"github.com/rahmatrdn/go-skeleton/config"
+	_ "github.com/rahmatrdn/go-skeleton/docs"
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/http/handler"
+	"github.com/rahmatrdn/go-skeleton/internal/http/middleware"
+	"github.com/rahmatrdn/go-skeleton/internal/logger"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/subosito/gotenv"
+	"go.uber.org/zap"
+)
+
+func init() {
+	_ = gotenv.Load()
+}
+
+// Entry point of the lite profile: no database, queue or auth, only the HTTP
+// server with structured logging to stdout. The generator moves it to cmd/api.
+//
+// @title 						Go Skeleton!
+// @version 					1.0
+// @description 				This is a sample swagger for Go Skeleton
+// @termsOfService 				http://swagger.io/terms/
+// @contact.name 				API Support
+// @contact.email 				rahmat.putra@spesolution.com
+// @license.name				Apache 2.0
+// @license.url 				http://www.apache.org/licenses/LICENSE-2.0.html
+// @host 						localhost:7011
+// @BasePath /
+func main() {
+	// Initialize config variable from .env file
+	cfg := config.NewConfig()
+
+	zapLogger, err := config.NewConsoleLogger()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer zapLogger.Sync()
+	// logger.FromContext(ctx) logs with it
+	logger.SetBase(zapLogger)
+	// LOG_LEVEL, and reloads it from .env on SIGHUP
+	cfg.ApplyReloadable()
+	config.WatchReload(context.Background(), zapLogger)
+
+	app := fiber.New(config.NewFiberConfiguration(cfg, zapLogger))
+	app.Get("/apidoc/*", swagger.HandlerDefault)
+
+	// Middleware setup
+	setupMiddleware(app, cfg, zapLogger)
+
+	api := app.Group("/api/v1")
+
+	handler.NewExampleHandler(zapLogger).Register(api)
+
+	app.Get("/health-check", healthCheck)
+	handler.NewVersionHandler(entity.Version{Name: cfg.AppName, Version: cfg.AppVersion, Commit: config.Commit, BuildTime: config.BuildTime}).Register(app)
+
+	// Handle Route not found
+	app.Use(routeNotFound)
+
+	runServerWithGracefulShutdown(app, cfg.ApiPort, 30)
+}
+
+func setupMiddleware(app *fiber.App, cfg *config.Config, zapLogger *zap.Logger) {
+	app.Use(
+		config.NewCORS(cfg),
+		requestid.New(),
+		middleware.AccessLog(zapLogger, middleware.AccessLogConfigDefault),
+		middleware.Recover(zapLogger, nil),
+		config.NewCompression(cfg),
+	)
+}
+
+func runServerWithGracefulShutdown(app *fiber.App, apiPort string, shutdownTimeout int) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// Run server in a goroutine
+	go func() {
+		defer wg.Done()
+		log.Printf("Starting REST server, listening at %s\n", apiPort)
+		if err := app.Listen(apiPort); err != nil {
+			log.Fatalf("Server failed: %v", err)
+		}
+	}()
+
+	// Capture OS signals for graceful shutdown
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+
+	log.Println("Shutting down REST server...")
+
+	// Timeout context for shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(shutdownTimeout)*time.Second)
+	defer cancel()
+
+	if err := app.ShutdownWithContext(ctx); err != nil {
+		log.Printf("Error during server shutdown: %v", err)
+	} else {
+		log.Println("REST server shut down gracefully")
+	}
+
+	// Wait for goroutines to exit
+	wg.Wait()
+	log.Println("All tasks completed. Exiting application.")
+}
+
+var healthCheck = func(c *fiber.Ctx) error {
+	return c.JSON(entity.GeneralResponse{
+		Code:    200,
+		Message: "OK!",
+	})
+}
+
+var routeNotFound = func(c *fiber.Ctx) error {
+	return c.Status(404).JSON(entity.GeneralResponse{
+		Code:    404,
+		Message: "Route Not Found!",
+	})
+}
@@ -2,11 +2,9 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"os"
 	"os/signal"
-	"runtime/debug"
 	"sync"
 	"syscall"
 	"time"
@@ -14,20 +12,27 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/monitor"
 	"github.com/gofiber/swagger"
 	"github.com/rahmatrdn/go-skeleton/config"
+	"github.com/rahmatrdn/go-skeleton/database/migration"
 	_ "github.com/rahmatrdn/go-skeleton/docs"
 	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/health"
 	"github.com/rahmatrdn/go-skeleton/internal/http/auth"
 	"github.com/rahmatrdn/go-skeleton/internal/http/handler"
+	"github.com/rahmatrdn/go-skeleton/internal/http/middleware"
+	"github.com/rahmatrdn/go-skeleton/internal/logger"
+	"github.com/rahmatrdn/go-skeleton/internal/metrics"
 	"github.com/rahmatrdn/go-skeleton/internal/parser"
 	"github.com/rahmatrdn/go-skeleton/internal/presenter/json"
+	"github.com/rahmatrdn/go-skeleton/internal/realtime"
 	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql"
 	"github.com/rahmatrdn/go-skeleton/internal/usecase"
 	todo_list_usecase "github.com/rahmatrdn/go-skeleton/internal/usecase/todo_list"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/logger"
-	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/subosito/gotenv"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 func init() {
@@ -41,47 +46,75 @@ func init() {
 // @contact.name 				API Support
 // @contact.email 				rahmat.putra@spesolution.com
 // @license.name				Apache 2.0
-// @securityDefinitions.apikey 	Bearer
-// @in							header
-// @name						Authorization
 // @license.url 				http://www.apache.org/licenses/LICENSE-2.0.html
 // @host 						localhost:7011
 // @BasePath /
+//
+// The JWT of /api/v1/auth/login, sent by the Authorize button of the Swagger UI
+// @securityDefinitions.apikey 	Bearer
+// @in							header
+// @name						Authorization
+// @description					Type "Bearer" followed by a space and the token of /api/v1/auth/login
 func main() {
 	// Initialize config variable from .env file
 	cfg := config.NewConfig()
 
-	app := fiber.New(config.NewFiberConfiguration(cfg))
+	zapLogger, err := config.NewZapLog(cfg.AppEnv)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer zapLogger.Sync()
+	// logger.FromContext(ctx) logs with it
+	logger.SetBase(zapLogger)
+	// LOG_LEVEL, and reloads it from .env on SIGHUP
+	cfg.ApplyReloadable()
+	config.WatchReload(context.Background(), zapLogger)
+
+	app := fiber.New(config.NewFiberConfiguration(cfg, zapLogger))
 	app.Get("/apidoc/*", swagger.HandlerDefault)
 
 	// Middleware setup
-	setupMiddleware(app, cfg)
-
-	// logger, _ := config.NewZapLog(cfg.AppEnv)
-	// logger = logger.WithOptions(zap.AddCallerSkip(1))
+	setupMiddleware(app, cfg, zapLogger)
 
 	presenterJson := json.NewJsonPresenter()
 	parser := parser.NewParser()
 
-	// RabbitMQ Configuration (if needed)
-	// queue, err := config.NewRabbitMQInstance(context.Background(), &cfg.RabbitMQOption)
-	// if err != nil {zp
+	// The dependencies are retried until they answer, for STARTUP_WAIT_SECONDS at most,
+	// so the API doesn't crash when it starts before their containers are ready
+	startup, cancelStartup := context.WithTimeout(context.Background(), time.Duration(cfg.StartupWaitSeconds)*time.Second)
+	defer cancelStartup()
+
+	// Queue Configuration (if needed), QUEUE_DRIVER picks RabbitMQ or the in-memory queue
+	// queue, err := health.Open(startup, zapLogger, "queue", func(context.Context) (queue.Queue, error) {
+	// 	return config.NewQueue(context.Background(), cfg)
+	// })
+	// if err != nil {
 	// 	log.Fatal(err)
 	// }
 
 	// Redis Configuration (if needed)
 	// redisDB := config.NewRedis(&cfg.RedisOption)
+	// if err := health.WaitReady(startup, zapLogger, "redis", health.Redis(redisDB)); err != nil {
+	// 	log.Fatal(err)
+	// }
 
-	// MySQL/MariaDB Initialization
-	gormLogger := config.NewGormLogMysqlConfig(&cfg.MysqlOption)
-	mysqlDB, err := config.NewMysql(cfg.AppEnv, &cfg.MysqlOption, gormLogger)
+	// SQL Initialization, MySQL/MariaDB or PostgreSQL/CockroachDB as generated
+	db, err := health.Open(startup, zapLogger, "database", func(context.Context) (*gorm.DB, error) {
+		return config.NewSQL(cfg, zapLogger)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	dbCollector, err := metrics.NewSQLCollector(db.Dialector.Name(), db)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	// PostgreSQL Initialization
-	// gormLogger := config.NewGormLogPostgreConfig(&cfg.MysqlOption)
-	// postgreDB, err := config.NewPostgreSQL(cfg.AppEnv, &cfg.PostgreSqlOption, gormLogger)
+	// MongoDB Initialization, the repositories are generated in internal/repository/mongodb
+	// dbCollector := metrics.NewMongoCollector()
+	// db, err := health.Open(startup, zapLogger, "database", func(ctx context.Context) (*mongo.Database, error) {
+	// 	return config.NewMongodb(ctx, &cfg.MongodbOption, options.Client().SetPoolMonitor(dbCollector.PoolMonitor()))
+	// })
 	// if err != nil {
 	// 	log.Fatal(err)
 	// }
@@ -90,21 +123,45 @@ func main() {
 	jwtAuth := auth.NewJWTAuth()
 
 	// REPOSITORY : Write repository code here (database, cache, etc.)
-	userRepo := mysql.NewUserRepository(mysqlDB)
-	todoListRepo := mysql.NewTodoListRepository(mysqlDB)
+	userRepo := mysql.NewUserRepository(db)
+	todoListRepo := mysql.NewTodoListRepository(db)
+	// Cache todo list reads in Redis, writes invalidate the cached entries (needs redisDB)
+	// todoListRepo := cache.NewTodoListRepository(mysql.NewTodoListRepository(db), cache.NewRedisCache(redisDB), 5*time.Minute)
 
 	// USECASE : Write bussines logic code here (validation, business logic, etc.)
 	// _ = usecase.NewLogUsecase(queue)  // LogUsecase is a sample usecase for sending log to queue (Mongodb, ElasticSearch, etc.)
 	userUsecase := usecase.NewUserUsecase(userRepo, jwtAuth)
 	crudTodoListUsecase := todo_list_usecase.NewCrudTodoListUsecase(todoListRepo)
 
+	// Real-time: backend events published with realtime.Publish reach the clients of
+	// /ws (WebSocket) and /events (SSE) once the queue is consumed (needs queue)
+	hub := realtime.NewHub()
+	// go queue.HandleBroadcastDeliveries(realtime.EventTopic, hub.HandleEvent)
+	handler.NewWebSocketHandler(hub).Register(app)
+	handler.NewSSEHandler(hub, 15*time.Second).Register(app)
+
+	// GraphQL on /graphql, the resolvers in internal/graphql call the same usecases
+	handler.NewGraphQLHandler(crudTodoListUsecase, zapLogger, cfg.GraphQLOnly).Register(app)
+
 	api := app.Group("/api/v1")
 
 	handler.NewAuthHandler(parser, presenterJson, userUsecase).Register(api)
 	handler.NewTodoListHandler(parser, presenterJson, crudTodoListUsecase).Register(api)
 
 	app.Get("/health-check", healthCheck)
-	app.Get("/metrics", monitor.New())
+	handler.NewVersionHandler(entity.Version{Name: cfg.AppName, Version: cfg.AppVersion, Commit: config.Commit, BuildTime: config.BuildTime}).Register(app)
+	// Readiness on /readyz: the database answers and is on the latest migration
+	handler.NewReadinessHandler(map[string]health.Check{
+		"database":   health.Ping(db),
+		"migrations": health.Migrations(db, migration.Files),
+	}, 3*time.Second).Register(app)
+	app.Get("/monitor", monitor.New())
+
+	// Prometheus metrics on /metrics, with the connection pool stats of the stores
+	registry := metrics.NewRegistry()
+	registry.MustRegister(dbCollector)
+	// registry.MustRegister(metrics.NewRedisCollector(redisDB))
+	app.Get("/metrics", metrics.Handler(registry))
 
 	// Handle Route not found
 	app.Use(routeNotFound)
@@ -112,33 +169,17 @@ func main() {
 	runServerWithGracefulShutdown(app, cfg.ApiPort, 30)
 }
 
-func setupMiddleware(app *fiber.App, cfg *config.Config) {
-	// Enable CORS if API shared in public
-	// if cfg.AppEnv == "production" {
-	// 	app.Use(
-	// 		cors.New(cors.Config{
-	// 			AllowCredentials: true,
-	// 			AllowOrigins:     cfg.AllowedCredentialOrigins,
-	// 			AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
-	// 			AllowMethods:     "GET,POST,PUT,DELETE,PATCH",
-	// 		}),
-	// 	)
-	// }
-
+func setupMiddleware(app *fiber.App, cfg *config.Config, zapLogger *zap.Logger) {
 	app.Use(
-		logger.New(logger.Config{
-			Format:     "[${time}] ${status} - ${latency} ${method} ${path}\n",
-			TimeFormat: "02-Jan-2006 15:04:05",
-			TimeZone:   "Asia/Jakarta",
-		}),
-		recover.New(recover.Config{
-			StackTraceHandler: func(c *fiber.Ctx, e interface{}) {
-				fmt.Println(c.Request().URI())
-				stacks := fmt.Sprintf("panic: %v\n%s\n", e, debug.Stack())
-				log.Println(stacks)
-			},
-			EnableStackTrace: true,
-		}),
+		// CORS for the browsers of ALLOWED_CREDENTIAL_ORIGINS, off when it's empty
+		config.NewCORS(cfg),
+		requestid.New(),
+		// Access logs, secrets in headers and JSON bodies are redacted (see AccessLogConfig)
+		middleware.AccessLog(zapLogger, middleware.AccessLogConfigDefault),
+		// Panics answer a 500 and are logged with their stack, pass the LogUsecase
+		// instead of nil to also publish them (needs queue)
+		middleware.Recover(zapLogger, nil),
+		config.NewCompression(cfg),
 	)
 }
 
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rahmatrdn/go-skeleton/config"
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/http/auth"
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql"
+	"github.com/rahmatrdn/go-skeleton/internal/usecase"
+	"github.com/subosito/gotenv"
+)
+
+// passwordEnv holds the password of the admin, kept out of the command line
+// and the shell history
+const passwordEnv = "BOOTSTRAP_ADMIN_PASSWORD"
+
+func init() {
+	_ = gotenv.Load()
+}
+
+// bootstrap creates the first admin of a fresh deploy and prints a token for
+// it, run it with make bootstrap email=admin@example.com and the password in
+// BOOTSTRAP_ADMIN_PASSWORD. It does nothing when the user already exists, so
+// it can run on every deploy.
+func main() {
+	email := flag.String("email", "", "Email of the admin")
+	name := flag.String("name", "Admin", "Name of the admin")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Println("CONFIG INVALID:")
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	db, err := config.NewSQL(cfg, nil)
+	if err != nil {
+		fmt.Println("DATABASE UNREACHABLE:", err.Error())
+		os.Exit(1)
+	}
+
+	userUsecase := usecase.NewUserUsecase(mysql.NewUserRepository(db), auth.NewJWTAuth())
+	admin, err := userUsecase.BootstrapAdmin(context.Background(), &entity.BootstrapAdminReq{
+		Name:     *name,
+		Email:    *email,
+		Password: os.Getenv(passwordEnv),
+	})
+	if err != nil {
+		fmt.Println("FAILED TO CREATE THE ADMIN:", err.Error())
+		os.Exit(1)
+	}
+	if admin == nil {
+		fmt.Println("AN ADMIN ALREADY EXISTS, NOTHING TO DO")
+		return
+	}
+
+	fmt.Printf("ADMIN %s CREATED WITH ID %d, ITS FIRST TOKEN:\n", admin.Email, admin.UserID)
+	fmt.Println(admin.Token)
+}
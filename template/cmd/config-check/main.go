@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/config"
+	"github.com/rahmatrdn/go-skeleton/internal/health"
+	"github.com/subosito/gotenv"
+)
+
+func init() {
+	_ = gotenv.Load()
+}
+
+// config-check validates the configuration and pings the services it points
+// at, exiting non-zero with a report when anything fails. Run it in the deploy
+// pipeline before rolling out: make config_check
+func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Println("CONFIG INVALID:")
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	checks := map[string]health.Check{}
+
+	// SQL database, MySQL/MariaDB or PostgreSQL/CockroachDB as generated
+	db, err := config.NewSQL(cfg, nil)
+	if err != nil {
+		checks["database"] = health.Result(err)
+	} else {
+		checks["database"] = health.Ping(db)
+	}
+
+	// MongoDB
+	// mongoDB, err := config.NewMongodb(context.Background(), &cfg.MongodbOption)
+	// if err != nil {
+	// 	checks["database"] = health.Result(err)
+	// } else {
+	// 	checks["database"] = health.Mongo(mongoDB)
+	// }
+
+	// Redis
+	checks["redis"] = health.Redis(config.NewRedis(&cfg.RedisOption))
+
+	// Queue, picked by QUEUE_DRIVER: connecting is the check
+	_, err = config.NewQueue(context.Background(), cfg)
+	checks["queue"] = health.Result(err)
+
+	results := health.Run(context.Background(), checks, 5*time.Second)
+
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	failed := false
+	for _, name := range names {
+		if err := results[name]; err != nil {
+			failed = true
+			fmt.Println(fmt.Sprintf("%-10s FAILED: %s", name, err.Error()))
+		} else {
+			fmt.Println(fmt.Sprintf("%-10s OK", name))
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+	fmt.Println("CONFIG OK!")
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rahmatrdn/go-skeleton/config"
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mongodb"
+	"github.com/subosito/gotenv"
+)
+
+func init() {
+	_ = gotenv.Load()
+}
+
+// db-reset drops the local MongoDB database and creates the indexes again, run
+// it with make db_reset. It refuses to touch a production database.
+func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Println("CONFIG INVALID:")
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+	if cfg.AppEnv == entity.PRODUCTION_ENV {
+		fmt.Println("REFUSING TO RESET THE DATABASE WITH APP_ENV=production")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	db, err := config.NewMongodb(ctx, &cfg.MongodbOption)
+	if err != nil {
+		fmt.Println("DATABASE UNREACHABLE:", err.Error())
+		os.Exit(1)
+	}
+
+	if err := db.Drop(ctx); err != nil {
+		fmt.Println("FAILED TO DROP THE DATABASE:", err.Error())
+		os.Exit(1)
+	}
+	if err := mongodb.NewLogRepository(db).EnsureIndexes(ctx); err != nil {
+		fmt.Println("FAILED TO CREATE THE INDEXES:", err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Println(fmt.Sprintf("DATABASE %s RESET!", cfg.MongodbOption.DatabaseName))
+}
@@ -16,10 +16,17 @@ func init() {
 }
 
 func main() {
-	location, _ := time.LoadLocation("Asia/Jakarta")
+	location := helper.Location()
+	// Jobs read the time from timeSource, pass them a helper.FakeClock in tests
+	timeSource := helper.Clock(helper.RealClock{})
+
+	// Redis lock (if needed): lets a single replica run each job, keep the TTL
+	// shorter than the shortest job interval
+	// locker := lock.NewLocker(config.NewRedis(&config.NewConfig().RedisOption))
 
 	s, err := gocron.NewScheduler(
 		gocron.WithLocation(location),
+		// gocron.WithDistributedLocker(locker.Scheduler(3*time.Second)),
 	)
 	if err != nil {
 		log.Fatal(err)
@@ -28,7 +35,7 @@ func main() {
 	fmt.Println("Starting scheduler...")
 
 	// cfg := config.NewConfig()
-	// queue, err := config.NewRabbitMQInstance(context.Background(), &cfg.RabbitMQOption)
+	// queue, err := config.NewQueue(context.Background(), cfg)
 	// if err != nil {
 	// 	log.Fatal(err)
 	// }
@@ -42,6 +49,7 @@ func main() {
 			func(a string, b int) {
 				// do things
 				fmt.Println("uwu")
+				fmt.Println("job ran at " + helper.FormatTime(timeSource.Now(), time.RFC3339))
 
 				helper.LogInfo("Process", "func_name", entity.CaptureFields{}, "message")
 			},
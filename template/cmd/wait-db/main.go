@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/config"
+	"github.com/rahmatrdn/go-skeleton/internal/health"
+	"github.com/subosito/gotenv"
+	"gorm.io/gorm"
+)
+
+func init() {
+	_ = gotenv.Load()
+}
+
+// wait-db retries connecting to the database with backoff until it answers,
+// exiting non-zero when it never does. make migrate_up runs it first, so the
+// migrations don't fail while a CI database container is still starting.
+func main() {
+	attempts := flag.Int("attempts", 10, "connection attempts before giving up")
+	interval := flag.Duration("interval", time.Second, "wait after the first failed attempt, doubled after each one")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Println("CONFIG INVALID:")
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	attempt := 0
+	check := func(ctx context.Context) error {
+		attempt++
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		db, err := connect(cfg)
+		if err == nil {
+			err = health.Ping(db)(ctx)
+		}
+		if err != nil {
+			fmt.Println(fmt.Sprintf("DATABASE NOT READY (ATTEMPT %d/%d): %s", attempt, *attempts, err.Error()))
+		}
+		return err
+	}
+
+	if err := health.WaitFor(context.Background(), check, *attempts, *interval); err != nil {
+		fmt.Println("DATABASE UNREACHABLE:", err.Error())
+		os.Exit(1)
+	}
+	fmt.Println("DATABASE READY!")
+}
+
+// connect opens the database, which pings it, so a failed open is retried too
+func connect(cfg *config.Config) (*gorm.DB, error) {
+	return config.NewSQL(cfg, nil)
+}
@@ -6,8 +6,10 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/rahmatrdn/go-skeleton/config"
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
 	"github.com/rahmatrdn/go-skeleton/internal/queue"
 	"github.com/rahmatrdn/go-skeleton/internal/queue/consumer"
 	"github.com/rahmatrdn/go-skeleton/internal/repository/mongodb"
@@ -44,23 +46,33 @@ func main() {
 	}
 	defer app.mongoDB.Client().Disconnect(app.ctx)
 
-	// gormLogger := config.NewGormLogConfig(&cfg.MysqlOption)
-	// mysqlDB, err := config.NewMysql(cfg.AppEnv, &cfg.MysqlOption, gormLogger)
+	// db, err := config.NewSQL(cfg, nil)
 	// if err != nil {
 	// 	log.Fatal(err)
 	// }
 
-	app.queue, err = config.NewRabbitMQInstance(app.ctx, &cfg.RabbitMQOption)
+	app.queue, err = config.NewRabbitMQInstance(app.ctx, cfg.AppName, &cfg.RabbitMQOption)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	// MongoDB Repository
 	logMongoRepo := mongodb.NewLogRepository(app.mongoDB)
+	if err := logMongoRepo.EnsureIndexes(app.ctx); err != nil {
+		log.Fatal(err)
+	}
 
 	// Consumer
-	logConsumer := consumer.NewLogConsumer(context.Background(), logMongoRepo)
-	exampleConsumer := consumer.NewExampleConsumer(context.Background(), logMongoRepo)
+	logConsumer := consumer.NewLogConsumer(context.Background(), logMongoRepo, helper.RealClock{})
+	var batchLogConsumer *consumer.BatchLogQueue
+	if cfg.LogBatchSize > 1 {
+		if cfg.LogBatchSize > cfg.ConsumerConcurrency {
+			log.Printf("[Worker] RABBITMQ_LOG_BATCH_SIZE is above RABBITMQ_CONSUMER_CONCURRENCY, batches will be written every %dms with at most %d logs", cfg.LogBatchIntervalMs, cfg.ConsumerConcurrency)
+		}
+		batchLogConsumer = consumer.NewBatchLogConsumer(context.Background(), logMongoRepo, cfg.LogBatchSize, time.Duration(cfg.LogBatchIntervalMs)*time.Millisecond, helper.RealClock{})
+		logConsumer = batchLogConsumer
+	}
+	exampleConsumer := consumer.NewExampleConsumer(context.Background())
 
 	var interrupt = make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
@@ -79,6 +91,12 @@ func main() {
 	<-interrupt
 	log.Println("Shutting down the Worker...")
 
+	if batchLogConsumer != nil {
+		if err := batchLogConsumer.Close(); err != nil {
+			log.Printf("Fail writing the last log batch: %s\n", err.Error())
+		}
+	}
+
 	if err = app.queue.Close(); err != nil {
 		log.Printf("Fail shutting down Worker: %s\n", err.Error())
 	} else {
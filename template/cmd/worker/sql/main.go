@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rahmatrdn/go-skeleton/config"
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
+	"github.com/rahmatrdn/go-skeleton/internal/queue"
+	"github.com/rahmatrdn/go-skeleton/internal/queue/consumer"
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql"
+	"github.com/subosito/gotenv"
+	"gorm.io/gorm"
+)
+
+func init() {
+	_ = gotenv.Load()
+}
+
+type GoSkeletonWorker struct {
+	ctx   context.Context
+	db    *gorm.DB
+	queue queue.Queue
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal("[Worker] topic not found, please use 'go run cmd/worker/main.go your.topic-key'")
+	}
+
+	log.Println("Starting WORKER")
+
+	var app GoSkeletonWorker
+	var err error
+
+	app.ctx = context.Background()
+	cfg := config.NewConfig()
+
+	app.db, err = config.NewSQL(cfg, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	app.queue, err = config.NewRabbitMQInstance(app.ctx, cfg.AppName, &cfg.RabbitMQOption)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// SQL Repository
+	logSqlRepo := mysql.NewLogRepository(app.db)
+
+	// Consumer
+	logConsumer := consumer.NewSqlLogConsumer(context.Background(), logSqlRepo, helper.RealClock{})
+	exampleConsumer := consumer.NewExampleConsumer(context.Background())
+
+	var interrupt = make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+
+	switch os.Args[1] {
+	case queue.ProcessSyncLog:
+		log.Printf("[Worker] Listening to %v", queue.ProcessSyncLog)
+		go app.queue.HandleConsumedDeliveries(queue.ProcessSyncLog, logConsumer.ProcessSyncLog)
+	case queue.ProcessExample:
+		log.Printf("[Worker] Listening to %v", queue.ProcessExample)
+		go app.queue.HandleConsumedDeliveries(queue.ProcessExample, exampleConsumer.Process)
+	default:
+		log.Fatalf("[Worker] topic not found : %v", os.Args[1])
+	}
+
+	<-interrupt
+	log.Println("Shutting down the Worker...")
+
+	if err = app.queue.Close(); err != nil {
+		log.Printf("Fail shutting down Worker: %s\n", err.Error())
+	} else {
+		log.Println("Worker successfully shutdown")
+	}
+
+}
@@ -1,21 +1,58 @@
 package config
 
-import "github.com/joeshaw/envdecode"
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joeshaw/envdecode"
+	"go.uber.org/zap/zapcore"
+)
 
 var StorageDirectory = "./storage/app/"
 
+// Version, Commit and BuildTime are set at build time with -ldflags, see the
+// Makefile's build target. Version takes precedence over APP_VERSION.
+var (
+	Version   string
+	Commit    string
+	BuildTime string
+)
+
+// Config is read from the environment by envdecode, the desc tags document the
+// variables in the README
 type Config struct {
-	AppName                  string   `env:"APP_NAME"`
-	AppVersion               string   `env:"APP_VERSION"`
-	AppEnv                   string   `env:"APP_ENV,default=development"`
-	ApiHost                  string   `env:"API_HOST"`
-	ApiRpcPort               string   `env:"API_RPC_PORT"`
-	ApiPort                  string   `env:"API_PORT,default=8760"`
-	ApiDocPort               uint16   `env:"API_DOC_PORT,default=8761"`
-	ShutdownTimeout          uint     `env:"API_SHUTDOWN_TIMEOUT_SECONDS,default=30"`
-	AllowedCredentialOrigins []string `env:"ALLOWED_CREDENTIAL_ORIGINS"`
-	MiddlewareAddress        string   `env:"MIDDLEWARE_ADDR"`
-	JwtExpireDaysCount       int      `env:"JWT_EXPIRE_DAYS_COUNT"`
+	AppName                  string   `desc:"Service name reported by /version and in the logs" env:"APP_NAME"`
+	AppVersion               string   `desc:"Version reported by /version when the binary isn't built with one" env:"APP_VERSION"`
+	AppEnv                   string   `desc:"production, dev or local" env:"APP_ENV,default=development"`
+	AppTimezone              string   `desc:"Timezone of helper.Now and the stored timestamps" env:"APP_TIMEZONE,default=Asia/Jakarta"`
+	ApiHost                  string   `desc:"Host the API is reached on" env:"API_HOST"`
+	ApiRpcPort               string   `desc:"Port of the gRPC server" env:"API_RPC_PORT"`
+	ApiPort                  string   `desc:"Listen address of the API, :7011 or a bare port" env:"API_PORT,default=:7011"`
+	ApiDocPort               uint16   `desc:"Port of the Swagger docs" env:"API_DOC_PORT,default=8761"`
+	LogLevel                 string   `desc:"debug, info, warn or error, reloaded on SIGHUP" env:"LOG_LEVEL,default=debug"`
+	ShutdownTimeout          uint     `desc:"Time given to in-flight requests on shutdown" env:"API_SHUTDOWN_TIMEOUT_SECONDS,default=30"`
+	StartupWaitSeconds       int      `desc:"How long the API retries the database, Redis and RabbitMQ on startup, 0 tries them once" env:"STARTUP_WAIT_SECONDS,default=30"`
+	BodyLimit                int      `desc:"Largest request body accepted" env:"API_BODY_LIMIT_BYTES,default=4194304"`
+	Compression              bool     `desc:"Compress the responses with gzip, br or deflate, as the client accepts" env:"API_COMPRESSION,default=false"`
+	AllowedCredentialOrigins []string `desc:"Comma-separated origins allowed to call the API from a browser with cookies, like https://*.example.com, empty turns CORS off" env:"ALLOWED_CREDENTIAL_ORIGINS"`
+	CORSAllowHeaders         string   `desc:"Comma-separated request headers cross-origin requests may send, empty for Origin, Content-Type, Accept, Authorization and X-API-Key" env:"CORS_ALLOW_HEADERS"`
+	CORSExposeHeaders        string   `desc:"Comma-separated response headers cross-origin requests can read" env:"CORS_EXPOSE_HEADERS,default=X-Request-ID"`
+	CORSMaxAge               int      `desc:"Seconds browsers cache a preflight response, 0 leaves it to the browser" env:"CORS_MAX_AGE_SECONDS,default=600"`
+	MiddlewareAddress        string   `desc:"Address of the auth middleware service" env:"MIDDLEWARE_ADDR"`
+	JwtExpireDaysCount       int      `desc:"Days before the issued JWT tokens expire" env:"JWT_EXPIRE_DAYS_COUNT"`
+	ApiKeys                  string   `desc:"Comma-separated API keys, each optionally followed by =scope|scope" env:"API_KEYS"`
+	OidcIssuerURL            string   `desc:"Issuer of the accepted OIDC tokens" env:"OIDC_ISSUER_URL"`
+	OidcAudience             string   `desc:"Audience of the accepted OIDC tokens" env:"OIDC_AUDIENCE"`
+	BatchSize                int      `desc:"Rows or documents written per statement by CreateBatch" env:"DB_BATCH_SIZE,default=500"`
+	TraceDB                  bool     `desc:"Log every SQL statement with its parameters at debug level, ignored unless APP_ENV is development, dev or local" env:"DB_TRACE,default=false"`
+	QueueDriver              string   `desc:"rabbitmq, or memory to keep the messages in the process" env:"QUEUE_DRIVER,default=rabbitmq"`
+	GraphQLOnly              bool     `desc:"Serve GraphQL only, the REST routes answer 404 except the auth ones" env:"GRAPHQL_ONLY,default=false"`
 	MysqlOption
 	RabbitMQOption
 	MongodbOption
@@ -23,44 +60,152 @@ type Config struct {
 	PostgreSqlOption
 }
 
-// MysqlOption contains mySQL connection options
+// MysqlOption contains mySQL connection options, the DSN is MYSQL_URI or built
+// from the host, port, user, password and database
 type MysqlOption struct {
-	URI           string `env:"MYSQL_URI,default="`
-	Pool          int    `env:"MYSQL_POOL,required"`
-	SlowThreshold int    `env:"MYSQL_SLOW_LOG_THRESHOLD,required"`
+	URI           string `desc:"MySQL DSN, takes precedence over the MYSQL_HOST to MYSQL_DATABASE_NAME settings" env:"MYSQL_URI,default="`
+	Host          string `desc:"MySQL host the DSN is built with when MYSQL_URI is empty" env:"MYSQL_HOST"`
+	Port          int    `desc:"MySQL port" env:"MYSQL_PORT,default=3306"`
+	User          string `desc:"MySQL user" env:"MYSQL_USER"`
+	Password      string `desc:"MySQL password" env:"MYSQL_PASSWORD"`
+	DatabaseName  string `desc:"MySQL database" env:"MYSQL_DATABASE_NAME"`
+	Pool          int    `desc:"Maximum open MySQL connections" env:"MYSQL_POOL,required"`
+	SlowThreshold int    `desc:"Milliseconds after which a MySQL query is logged as slow" env:"MYSQL_SLOW_LOG_THRESHOLD,required"`
 }
 
+// PostgreSqlOption contains PostgreSQL connection options, the DSN is
+// POSTGRE_URI or built from the host, port, user, password and database
 type PostgreSqlOption struct {
-	URI           string `env:"POSTGRE_URI,default="`
-	Pool          int    `env:"POSTGRE_POOL,default=1000"`
-	SlowThreshold int    `env:"POSTGRE_SLOW_LOG_THRESHOLD,default=200"`
+	URI           string `desc:"PostgreSQL DSN, takes precedence over the POSTGRE_HOST to POSTGRE_SSLMODE settings" env:"POSTGRE_URI,default="`
+	Host          string `desc:"PostgreSQL host the DSN is built with when POSTGRE_URI is empty" env:"POSTGRE_HOST"`
+	Port          int    `desc:"PostgreSQL port" env:"POSTGRE_PORT,default=5432"`
+	User          string `desc:"PostgreSQL user" env:"POSTGRE_USER"`
+	Password      string `desc:"PostgreSQL password" env:"POSTGRE_PASSWORD"`
+	DatabaseName  string `desc:"PostgreSQL database" env:"POSTGRE_DATABASE_NAME"`
+	SSLMode       string `desc:"sslmode of the built DSN" env:"POSTGRE_SSLMODE,default=disable"`
+	Pool          int    `desc:"Maximum open PostgreSQL connections" env:"POSTGRE_POOL,default=1000"`
+	SlowThreshold int    `desc:"Milliseconds after which a PostgreSQL query is logged as slow" env:"POSTGRE_SLOW_LOG_THRESHOLD,default=200"`
 }
 
 type RabbitMQOption struct {
-	Uri             string `env:"RABBITMQ_URI,required"`
-	Exchange        string `env:"RABBITMQ_EXCHANGE,default=events"`
-	QueueType       string `env:"RABBITMQ_QUEUE_TYPE,default=topic"`
-	QueuePrefix     string `env:"RABBITMQ_QUEUE_PREFIX,default=Ngorder API"`
-	QueueRetryCount int    `env:"RABBITMQ_RETRY_COUNT,default=3"`
+	Uri                 string `desc:"AMQP URI of RabbitMQ" env:"RABBITMQ_URI,required"`
+	Exchange            string `desc:"Exchange the messages are published to, derived from APP_NAME when empty" env:"RABBITMQ_EXCHANGE"`
+	QueueType           string `desc:"Exchange type" env:"RABBITMQ_QUEUE_TYPE,default=topic"`
+	QueuePrefix         string `desc:"Prefix of the queue names" env:"RABBITMQ_QUEUE_PREFIX"`
+	RoutingKeyPrefix    string `desc:"Prefix of the routing keys" env:"RABBITMQ_ROUTING_KEY_PREFIX"`
+	QueueRetryCount     int    `desc:"Attempts before a message is dropped" env:"RABBITMQ_RETRY_COUNT,default=3"`
+	ConsumerConcurrency int    `desc:"Messages a worker handles at the same time" env:"RABBITMQ_CONSUMER_CONCURRENCY,default=1"`
+	LogThrottle         string `desc:"Duplicate logs: none, sample or coalesce" env:"RABBITMQ_LOG_THROTTLE,default=none"`
+	LogThrottleWindowMs int    `desc:"Window of the log throttle" env:"RABBITMQ_LOG_THROTTLE_WINDOW_MS,default=1000"`
+	LogBatchSize        int    `desc:"Logs written to the log store at once" env:"RABBITMQ_LOG_BATCH_SIZE,default=1"`
+	LogBatchIntervalMs  int    `desc:"Longest wait before a partial log batch is written" env:"RABBITMQ_LOG_BATCH_INTERVAL_MS,default=1000"`
 }
 
+// MongodbOption contains MongoDB connection options, the URI is MONGODB_URI or
+// built from the host, port, user and password
 type MongodbOption struct {
-	Uri          string `env:"MONGODB_URI,required"`
-	DatabaseName string `env:"MONGODB_DATABASE_NAME,required"`
+	Uri          string `desc:"MongoDB URI, takes precedence over the MONGODB_HOST to MONGODB_PASSWORD settings, one of MONGODB_URI and MONGODB_HOST is required" env:"MONGODB_URI"`
+	Host         string `desc:"MongoDB host the URI is built with when MONGODB_URI is empty" env:"MONGODB_HOST"`
+	Port         int    `desc:"MongoDB port" env:"MONGODB_PORT,default=27017"`
+	User         string `desc:"MongoDB user, authenticated against the admin database" env:"MONGODB_USER"`
+	Password     string `desc:"MongoDB password" env:"MONGODB_PASSWORD"`
+	DatabaseName string `desc:"MongoDB database" env:"MONGODB_DATABASE_NAME,required"`
 }
 
 type RedisOption struct {
-	Host           string `env:"REDIS_HOST,required"`
-	Password       string `env:"REDIS_PASSWORD"`
-	ReadTimeoutMs  int16  `env:"REDIS_READ_TIMEOUT,required"`
-	WriteTimeoutMs int16  `env:"REDIS_WRITE_TIMEOUT,required"`
+	Host           string `desc:"Redis address, host:port" env:"REDIS_HOST,required"`
+	Password       string `desc:"Redis password" env:"REDIS_PASSWORD"`
+	ReadTimeoutMs  int16  `desc:"Redis read timeout in milliseconds" env:"REDIS_READ_TIMEOUT,required"`
+	WriteTimeoutMs int16  `desc:"Redis write timeout in milliseconds" env:"REDIS_WRITE_TIMEOUT,required"`
 }
 
 func NewConfig() *Config {
+	cfg, err := LoadConfig()
+	if err != nil {
+		panic(err)
+	}
+
+	return cfg
+}
+
+// LoadConfig reads the configuration from the environment, returning why it's
+// missing or malformed instead of panicking like NewConfig
+func LoadConfig() (*Config, error) {
 	var cfg Config
 	if err := envdecode.Decode(&cfg); err != nil {
-		panic(err)
+		return nil, err
+	}
+	if Version != "" {
+		cfg.AppVersion = Version
+	}
+
+	return &cfg, cfg.Validate()
+}
+
+// Validate reports the settings that decode fine but can't work
+func (c *Config) Validate() error {
+	errs := []error{}
+
+	// API_PORT is the listen address, :7011 or a bare port
+	port := c.ApiPort
+	if strings.Contains(port, ":") {
+		_, port, _ = net.SplitHostPort(port)
+	}
+	if number, err := strconv.Atoi(port); err != nil || number < 1 || number > 65535 {
+		errs = append(errs, fmt.Errorf("API_PORT %q is not a port", c.ApiPort))
 	}
+	if _, err := time.LoadLocation(c.AppTimezone); err != nil {
+		errs = append(errs, fmt.Errorf("APP_TIMEZONE %q is not a timezone", c.AppTimezone))
+	}
+	if _, err := zapcore.ParseLevel(c.LogLevel); err != nil {
+		errs = append(errs, fmt.Errorf("LOG_LEVEL %q is not debug, info, warn or error", c.LogLevel))
+	}
+	if c.BodyLimit <= 0 {
+		errs = append(errs, fmt.Errorf("API_BODY_LIMIT_BYTES must be positive, got %d", c.BodyLimit))
+	}
+	if c.StartupWaitSeconds < 0 {
+		errs = append(errs, fmt.Errorf("STARTUP_WAIT_SECONDS can't be negative, got %d", c.StartupWaitSeconds))
+	}
+	if c.BatchSize <= 0 {
+		errs = append(errs, fmt.Errorf("DB_BATCH_SIZE must be positive, got %d", c.BatchSize))
+	}
+	for _, origin := range splitList(strings.Join(c.AllowedCredentialOrigins, ",")) {
+		if !validOrigin(origin) {
+			errs = append(errs, fmt.Errorf("ALLOWED_CREDENTIAL_ORIGINS %q is not an origin like https://app.example.com or https://*.example.com", origin))
+		}
+	}
+	for name, headers := range map[string]string{"CORS_ALLOW_HEADERS": c.CORSAllowHeaders, "CORS_EXPOSE_HEADERS": c.CORSExposeHeaders} {
+		for _, header := range splitList(headers) {
+			if !headerName.MatchString(header) {
+				errs = append(errs, fmt.Errorf("%s %q is not a header name", name, header))
+			}
+		}
+	}
+	if c.CORSMaxAge < 0 {
+		errs = append(errs, fmt.Errorf("CORS_MAX_AGE_SECONDS can't be negative, got %d", c.CORSMaxAge))
+	}
+
+	return errors.Join(errs...)
+}
+
+// headerName is the token syntax of HTTP header names
+var headerName = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+.^_`|~-]+$")
+
+// splitList splits a comma-separated setting, dropping the empty entries
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
 
-	return &cfg
+// validOrigin accepts scheme://host[:port] origins, the host may start with
+// *. to allow its subdomains. A bare * can't be used with cookies.
+func validOrigin(origin string) bool {
+	u, err := url.Parse(strings.Replace(origin, "://*.", "://", 1))
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" &&
+		u.Path == "" && u.User == nil && u.RawQuery == "" && u.Fragment == ""
 }
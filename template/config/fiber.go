@@ -1,12 +1,23 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/rahmatrdn/go-skeleton/entity"
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+	"go.uber.org/zap"
 )
 
-func NewFiberConfiguration(cfg *Config) fiber.Config {
+// DefaultCORSAllowHeaders are the request headers allowed cross-origin when
+// CORS_ALLOW_HEADERS is empty
+const DefaultCORSAllowHeaders = "Origin,Content-Type,Accept,Authorization,X-API-Key"
+
+func NewFiberConfiguration(cfg *Config, zapLogger *zap.Logger) fiber.Config {
 	return fiber.Config{
 		CaseSensitive: true,
 		ColorScheme: fiber.Colors{
@@ -14,5 +25,86 @@ func NewFiberConfiguration(cfg *Config) fiber.Config {
 		},
 		StrictRouting: true,
 		AppName:       fmt.Sprintf("%s - %s", cfg.AppName, cfg.AppVersion),
+		// Fiber answers 413 to larger bodies, 0 keeps its 4MB default
+		BodyLimit: cfg.BodyLimit,
+		// Errors the handlers return get the body of apperr
+		ErrorHandler: NewErrorHandler(zapLogger),
+	}
+}
+
+// NewErrorHandler answers the errors returned by the handlers and middlewares,
+// wrapped or not, with the status and body of their apperr error. Fiber's own
+// errors, like the 413 of BodyLimit, keep their status and any other error is
+// a 500 with the general message. The 5xx are logged to zapLogger with the
+// whole error and the stack captured by apperr.Wrap, which the client never
+// sees.
+func NewErrorHandler(zapLogger *zap.Logger) fiber.ErrorHandler {
+	return func(c *fiber.Ctx, err error) error {
+		var appErr apperr.CustomErrorResponse
+		var fiberErr *fiber.Error
+		switch {
+		case errors.As(err, &appErr):
+		case errors.As(err, &fiberErr):
+			code := entity.BAD_REQUEST_CODE
+			if fiberErr.Code >= fiber.StatusInternalServerError {
+				code = entity.INTERNAL_ERROR_CODE
+			}
+			appErr = apperr.CustomError(fiberErr.Message, code, fiberErr.Code)
+		default:
+			appErr = apperr.Internal(err)
+		}
+
+		if appErr.HTTPCode >= fiber.StatusInternalServerError {
+			zapLogger.Error("request failed",
+				zap.String("request_id", c.GetRespHeader(fiber.HeaderXRequestID, c.Get(fiber.HeaderXRequestID))),
+				zap.String("method", c.Method()),
+				zap.String("path", c.Path()),
+				zap.Int("status", appErr.HTTPCode),
+				zap.Error(err),
+				zap.NamedError("cause", apperr.RootCause(err)),
+				zap.String("stack", apperr.StackTrace(err)),
+			)
+		}
+
+		return c.Status(appErr.HTTPCode).JSON(appErr)
+	}
+}
+
+// NewCompression compresses the responses with gzip, brotli or deflate, as the
+// client accepts them. It passes the responses through unless API_COMPRESSION is on.
+func NewCompression(cfg *Config) fiber.Handler {
+	if !cfg.Compression {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	return compress.New()
+}
+
+// NewCORS lets the browsers of ALLOWED_CREDENTIAL_ORIGINS call the API with
+// cookies. Preflight responses are cached for CORS_MAX_AGE_SECONDS and the
+// CORS_EXPOSE_HEADERS, the request ID by default, are readable by the client.
+// Without origins, cross-origin requests get no CORS headers.
+func NewCORS(cfg *Config) fiber.Handler {
+	origins := strings.Join(splitList(strings.Join(cfg.AllowedCredentialOrigins, ",")), ",")
+	if origins == "" {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	allowHeaders := cfg.CORSAllowHeaders
+	if strings.TrimSpace(allowHeaders) == "" {
+		allowHeaders = DefaultCORSAllowHeaders
 	}
+
+	return cors.New(cors.Config{
+		AllowCredentials: true,
+		AllowOrigins:     origins,
+		AllowHeaders:     allowHeaders,
+		AllowMethods:     "GET,POST,PUT,DELETE,PATCH",
+		ExposeHeaders:    cfg.CORSExposeHeaders,
+		MaxAge:           cfg.CORSMaxAge,
+	})
 }
@@ -0,0 +1,238 @@
+package config_test
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rahmatrdn/go-skeleton/config"
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newTestApp(cfg *config.Config) *fiber.App {
+	app := fiber.New(config.NewFiberConfiguration(cfg, zap.NewNop()))
+	app.Use(config.NewCompression(cfg))
+	app.Post("/echo", func(c *fiber.Ctx) error {
+		return c.Send(c.Body())
+	})
+	app.Get("/todo-lists", func(c *fiber.Ctx) error {
+		return c.SendString(strings.Repeat(`{"title":"Buy milk","done":false},`, 100))
+	})
+
+	return app
+}
+
+func TestBodyLimitRejectsOversizedBody(t *testing.T) {
+	// fasthttp answers 413 while reading the request, which app.Test bypasses
+	app := newTestApp(&config.Config{BodyLimit: 16})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go app.Listener(listener)
+	defer app.Shutdown()
+
+	url := "http://" + listener.Addr().String() + "/echo"
+
+	resp, err := http.Post(url, "text/plain", strings.NewReader(strings.Repeat("a", 17)))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, resp.StatusCode)
+	assert.JSONEq(t, `{"message":"Request Entity Too Large","code":"30","error_code":"PAYLOAD_TOO_LARGE","http_code":413}`, string(body))
+
+	resp, err = http.Post(url, "text/plain", strings.NewReader(strings.Repeat("a", 16)))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestCompression(t *testing.T) {
+	req := httptest.NewRequest("GET", "/todo-lists", nil)
+	req.Header.Set(fiber.HeaderAcceptEncoding, "gzip")
+
+	resp, err := newTestApp(&config.Config{Compression: true}).Test(req)
+	require.NoError(t, err)
+	require.Equal(t, "gzip", resp.Header.Get(fiber.HeaderContentEncoding))
+
+	reader, err := gzip.NewReader(resp.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat(`{"title":"Buy milk","done":false},`, 100), string(body))
+}
+
+func TestCompressionOff(t *testing.T) {
+	req := httptest.NewRequest("GET", "/todo-lists", nil)
+	req.Header.Set(fiber.HeaderAcceptEncoding, "gzip")
+
+	resp, err := newTestApp(&config.Config{}).Test(req)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Header.Get(fiber.HeaderContentEncoding))
+}
+
+func TestCORSPreflight(t *testing.T) {
+	app := fiber.New()
+	app.Use(config.NewCORS(&config.Config{
+		AllowedCredentialOrigins: []string{"https://*.example.com,http://localhost:3000"},
+		CORSExposeHeaders:        "X-Request-ID,X-Total-Count",
+		CORSMaxAge:               900,
+	}))
+	app.Get("/todo-lists", func(c *fiber.Ctx) error {
+		c.Set("X-Request-ID", "abc")
+		return c.SendString("[]")
+	})
+
+	req := httptest.NewRequest(fiber.MethodOptions, "/todo-lists", nil)
+	req.Header.Set(fiber.HeaderOrigin, "https://app.example.com")
+	req.Header.Set(fiber.HeaderAccessControlRequestMethod, fiber.MethodGet)
+	req.Header.Set(fiber.HeaderAccessControlRequestHeaders, "Authorization")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "https://app.example.com", resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "true", resp.Header.Get(fiber.HeaderAccessControlAllowCredentials))
+	assert.Equal(t, "900", resp.Header.Get(fiber.HeaderAccessControlMaxAge))
+	assert.Equal(t, config.DefaultCORSAllowHeaders, resp.Header.Get(fiber.HeaderAccessControlAllowHeaders))
+
+	// The exposed headers are sent with the actual request
+	req = httptest.NewRequest(fiber.MethodGet, "/todo-lists", nil)
+	req.Header.Set(fiber.HeaderOrigin, "http://localhost:3000")
+
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:3000", resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "X-Request-ID,X-Total-Count", resp.Header.Get(fiber.HeaderAccessControlExposeHeaders))
+
+	// Other origins get no CORS headers
+	req = httptest.NewRequest(fiber.MethodGet, "/todo-lists", nil)
+	req.Header.Set(fiber.HeaderOrigin, "https://evil.test")
+
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+}
+
+func TestCORSOff(t *testing.T) {
+	req := httptest.NewRequest(fiber.MethodOptions, "/todo-lists", nil)
+	req.Header.Set(fiber.HeaderOrigin, "https://app.example.com")
+	req.Header.Set(fiber.HeaderAccessControlRequestMethod, fiber.MethodGet)
+
+	resp, err := newTestApp(&config.Config{CORSMaxAge: 900}).Test(req)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	assert.Empty(t, resp.Header.Get(fiber.HeaderAccessControlMaxAge))
+}
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+		body string
+	}{
+		{
+			name: "apperr error",
+			err:  apperr.NotFound("Order not found"),
+			code: fiber.StatusNotFound,
+			body: `{"message":"Order not found","code":"30","error_code":"NOT_FOUND","http_code":404}`,
+		},
+		{
+			name: "wrapped apperr error",
+			err:  fmt.Errorf("get order: %w", apperr.Conflict("Email already used")),
+			code: fiber.StatusConflict,
+			body: `{"message":"Email already used","code":"30","error_code":"CONFLICT","http_code":409}`,
+		},
+		{
+			name: "apperr error with cause",
+			err:  apperr.Internal(errors.New("connection refused")),
+			code: fiber.StatusInternalServerError,
+			body: `{"message":"Something went wrong. Please try again later.","code":"99","error_code":"INTERNAL","http_code":500}`,
+		},
+		{
+			name: "fiber error",
+			err:  fiber.ErrMethodNotAllowed,
+			code: fiber.StatusMethodNotAllowed,
+			body: `{"message":"Method Not Allowed","code":"30","error_code":"BAD_REQUEST","http_code":405}`,
+		},
+		{
+			name: "fiber server error",
+			err:  fiber.ErrServiceUnavailable,
+			code: fiber.StatusServiceUnavailable,
+			body: `{"message":"Service Unavailable","code":"99","error_code":"INTERNAL","http_code":503}`,
+		},
+		{
+			name: "other error",
+			err:  errors.New("dial tcp: connection refused"),
+			code: fiber.StatusInternalServerError,
+			body: `{"message":"Something went wrong. Please try again later.","code":"99","error_code":"INTERNAL","http_code":500}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(config.NewFiberConfiguration(&config.Config{}, zap.NewNop()))
+			app.Get("/", func(c *fiber.Ctx) error {
+				return tt.err
+			})
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.code, resp.StatusCode)
+			assert.JSONEq(t, tt.body, string(body))
+		})
+	}
+}
+
+func TestErrorHandlerLogsStack(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	app := fiber.New(config.NewFiberConfiguration(&config.Config{}, zap.New(core)))
+	app.Get("/todo-lists/:id", func(c *fiber.Ctx) error {
+		return apperr.Wrap(queryTodoList(), "CrudTodoListUsecase.GetByID")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/todo-lists/1", nil))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	// The client only gets the general message
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	assert.JSONEq(t, `{"message":"Something went wrong. Please try again later.","code":"99","error_code":"INTERNAL","http_code":500}`, string(body))
+
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "CrudTodoListUsecase.GetByID: TodoListRepository.GetByID: dial tcp: connection refused", fields["error"])
+	assert.Equal(t, "dial tcp: connection refused", fields["cause"])
+	assert.Contains(t, fields["stack"], "config_test.queryTodoList")
+}
+
+func TestErrorHandlerDoesNotLogClientErrors(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	app := fiber.New(config.NewFiberConfiguration(&config.Config{}, zap.New(core)))
+	app.Get("/", func(c *fiber.Ctx) error {
+		return apperr.NotFound("Order not found")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.Zero(t, logs.Len())
+}
+
+func queryTodoList() error {
+	return apperr.Wrap(errors.New("dial tcp: connection refused"), "TodoListRepository.GetByID")
+}
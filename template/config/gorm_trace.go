@@ -0,0 +1,53 @@
+package config
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+	glogger "gorm.io/gorm/logger"
+)
+
+// dbTraceEnvs are the APP_ENVs DB_TRACE works in. The traced statements hold
+// the users' data, so it never turns on anywhere else.
+var dbTraceEnvs = []string{"development", "dev", "local"}
+
+// TraceDBEnabled reports whether every SQL statement is logged, DB_TRACE has
+// to be set and APP_ENV has to be a development one
+func (c *Config) TraceDBEnabled() bool {
+	return c.TraceDB && slices.Contains(dbTraceEnvs, c.AppEnv)
+}
+
+// gormTraceLogger logs every statement of GORM, with its bound parameters, to
+// zap at debug level on top of the GORM logger it wraps
+type gormTraceLogger struct {
+	glogger.Interface
+	zapLogger *zap.Logger
+}
+
+// NewGormTraceLogger returns dbLogger logging every SQL statement through
+// zapLogger when cfg.TraceDBEnabled(), dbLogger unchanged otherwise or
+// without a zapLogger
+func NewGormTraceLogger(cfg *Config, dbLogger glogger.Interface, zapLogger *zap.Logger) glogger.Interface {
+	if !cfg.TraceDBEnabled() || zapLogger == nil {
+		return dbLogger
+	}
+
+	return &gormTraceLogger{Interface: dbLogger, zapLogger: zapLogger.Named("sql")}
+}
+
+func (l *gormTraceLogger) LogMode(level glogger.LogLevel) glogger.Interface {
+	return &gormTraceLogger{Interface: l.Interface.LogMode(level), zapLogger: l.zapLogger}
+}
+
+func (l *gormTraceLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	l.Interface.Trace(ctx, begin, fc, err)
+
+	sql, rows := fc()
+	fields := []zap.Field{zap.String("sql", sql), zap.Int64("rows", rows), zap.Duration("elapsed", time.Since(begin))}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	l.zapLogger.Debug("sql statement", fields...)
+}
@@ -0,0 +1,51 @@
+package config_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	glogger "gorm.io/gorm/logger"
+)
+
+// traceStatement runs a statement through the GORM logger of cfg like GORM
+// does after executing it
+func traceStatement(cfg *config.Config) *observer.ObservedLogs {
+	core, logs := observer.New(zap.DebugLevel)
+	dbLogger := config.NewGormTraceLogger(cfg, glogger.Discard, zap.New(core)).LogMode(glogger.Warn)
+
+	dbLogger.Trace(context.Background(), time.Now(), func() (string, int64) {
+		return "SELECT * FROM `users` WHERE email = 'jane@example.com'", 1
+	}, nil)
+	return logs
+}
+
+func TestGormTraceLoggerLogsStatements(t *testing.T) {
+	for _, env := range []string{"development", "dev", "local"} {
+		logs := traceStatement(&config.Config{AppEnv: env, TraceDB: true})
+
+		require.Equal(t, 1, logs.Len(), env)
+		entry := logs.All()[0]
+		assert.Equal(t, zap.DebugLevel, entry.Level)
+		assert.Equal(t, "sql", entry.LoggerName)
+		assert.Equal(t, "SELECT * FROM `users` WHERE email = 'jane@example.com'", entry.ContextMap()["sql"])
+		assert.Equal(t, int64(1), entry.ContextMap()["rows"])
+	}
+}
+
+func TestGormTraceLoggerSuppressed(t *testing.T) {
+	for name, cfg := range map[string]*config.Config{
+		"production":         {AppEnv: "production", TraceDB: true},
+		"unknown env":        {AppEnv: "staging", TraceDB: true},
+		"DB_TRACE not set":   {AppEnv: "local"},
+		"production not set": {AppEnv: "production"},
+	} {
+		assert.Zero(t, traceStatement(cfg).Len(), name)
+		assert.False(t, cfg.TraceDBEnabled(), name)
+	}
+}
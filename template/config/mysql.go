@@ -1,6 +1,13 @@
 package config
 
 import (
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
 	gmysql "gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	glogger "gorm.io/gorm/logger"
@@ -16,7 +23,7 @@ func NewMysql(env string, cfg *MysqlOption, dbLogger glogger.Interface) (*Mysql,
 		logLevel = glogger.Info
 	}
 
-	db, err := gorm.Open(gmysql.Open(cfg.URI), &gorm.Config{
+	db, err := gorm.Open(gmysql.Open(cfg.DSN()), &gorm.Config{
 		Logger: dbLogger.LogMode(logLevel),
 	})
 	if err != nil {
@@ -27,3 +34,37 @@ func NewMysql(env string, cfg *MysqlOption, dbLogger glogger.Interface) (*Mysql,
 	sqlDB.SetMaxOpenConns(cfg.Pool)
 	return &Mysql{DB: db}, err
 }
+
+// DSN returns MYSQL_URI, or when it's empty the DSN of the host, port, user,
+// password and database, like root:root@tcp(mysql:3306)/go_skeleton?parseTime=true
+func (cfg *MysqlOption) DSN() string {
+	if cfg.URI != "" {
+		return cfg.URI
+	}
+
+	credentials := ""
+	if cfg.User != "" {
+		credentials = cfg.User
+		if cfg.Password != "" {
+			credentials += ":" + cfg.Password
+		}
+		credentials += "@"
+	}
+	return fmt.Sprintf("%stcp(%s)/%s?parseTime=true", credentials, net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)), cfg.DatabaseName)
+}
+
+func NewGormLogMysqlConfig(cfg *MysqlOption) glogger.Interface {
+	return glogger.New(
+		log.New(
+			os.Stdout,
+			"\r\n",
+			log.LstdFlags,
+		),
+		glogger.Config{
+			SlowThreshold:             time.Duration(cfg.SlowThreshold) * time.Millisecond,
+			LogLevel:                  glogger.Warn,
+			Colorful:                  false,
+			IgnoreRecordNotFoundError: true,
+		},
+	)
+}
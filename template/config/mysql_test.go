@@ -0,0 +1,34 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/rahmatrdn/go-skeleton/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMysqlDSN(t *testing.T) {
+	cfg := &config.MysqlOption{Host: "mysql", Port: 3306, User: "root", Password: "p@ss", DatabaseName: "go_skeleton"}
+	assert.Equal(t, "root:p@ss@tcp(mysql:3306)/go_skeleton?parseTime=true", cfg.DSN())
+
+	cfg = &config.MysqlOption{Host: "::1", Port: 3307, DatabaseName: "go_skeleton"}
+	assert.Equal(t, "tcp([::1]:3307)/go_skeleton?parseTime=true", cfg.DSN())
+}
+
+func TestMysqlDSNFromEnv(t *testing.T) {
+	setValidEnv(t)
+	t.Setenv("MYSQL_HOST", "mysql")
+	t.Setenv("MYSQL_USER", "root")
+	t.Setenv("MYSQL_PASSWORD", "root")
+	t.Setenv("MYSQL_DATABASE_NAME", "go_skeleton")
+
+	cfg, err := config.LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "root:root@tcp(mysql:3306)/go_skeleton?parseTime=true", cfg.MysqlOption.DSN())
+
+	// MYSQL_URI wins over the parts
+	t.Setenv("MYSQL_URI", "app:secret@tcp(localhost:3306)/app?parseTime=true")
+	cfg, err = config.LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "app:secret@tcp(localhost:3306)/app?parseTime=true", cfg.MysqlOption.DSN())
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rahmatrdn/go-skeleton/internal/queue"
+)
+
+const (
+	QueueDriverRabbitMQ = "rabbitmq"
+	// QueueDriverMemory only delivers messages within the process, for local
+	// development and tests without a broker
+	QueueDriverMemory = "memory"
+)
+
+// NewQueue returns the queue.Queue selected by QUEUE_DRIVER
+func NewQueue(ctx context.Context, cfg *Config) (queue.Queue, error) {
+	switch cfg.QueueDriver {
+	case QueueDriverRabbitMQ:
+		return NewRabbitMQInstance(ctx, cfg.AppName, &cfg.RabbitMQOption)
+	case QueueDriverMemory:
+		return queue.NewMemoryQueue(), nil
+	default:
+		return nil, fmt.Errorf("unknown QUEUE_DRIVER %q", cfg.QueueDriver)
+	}
+}
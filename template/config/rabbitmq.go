@@ -2,20 +2,44 @@ package config
 
 import (
 	"context"
+	"regexp"
+	"strings"
 
 	"github.com/rahmatrdn/go-skeleton/internal/queue"
 )
 
-func NewRabbitMQInstance(ctx context.Context, cfg *RabbitMQOption) (*queue.RabbitMQ, error) {
+// NewRabbitMQ builds the RabbitMQ queue without connecting. The exchange, queue
+// prefix and routing key prefix left empty in cfg are derived from appName, so
+// services sharing a broker don't collide.
+func NewRabbitMQ(ctx context.Context, appName string, cfg *RabbitMQOption) *queue.RabbitMQ {
+	name := brokerName(appName)
+
 	rabbit := &queue.RabbitMQ{
-		Ctx:        ctx,
-		Uri:        cfg.Uri,
-		Exchange:   cfg.Exchange,
-		Kind:       cfg.QueueType,
-		Prefix:     cfg.QueuePrefix,
-		RetryCount: cfg.QueueRetryCount,
-		Err:        make(chan error),
+		Ctx:              ctx,
+		Uri:              cfg.Uri,
+		Exchange:         cfg.Exchange,
+		Kind:             cfg.QueueType,
+		Prefix:           cfg.QueuePrefix,
+		RoutingKeyPrefix: cfg.RoutingKeyPrefix,
+		RetryCount:       cfg.QueueRetryCount,
+		Concurrency:      cfg.ConsumerConcurrency,
+		Err:              make(chan error),
+	}
+	if rabbit.Exchange == "" {
+		rabbit.Exchange = name + ".events"
+	}
+	if rabbit.Prefix == "" {
+		rabbit.Prefix = name
 	}
+	if rabbit.RoutingKeyPrefix == "" {
+		rabbit.RoutingKeyPrefix = name
+	}
+
+	return rabbit
+}
+
+func NewRabbitMQInstance(ctx context.Context, appName string, cfg *RabbitMQOption) (*queue.RabbitMQ, error) {
+	rabbit := NewRabbitMQ(ctx, appName, cfg)
 
 	if err := rabbit.Connect(); err != nil {
 		return nil, err
@@ -23,3 +47,14 @@ func NewRabbitMQInstance(ctx context.Context, cfg *RabbitMQOption) (*queue.Rabbi
 
 	return rabbit, nil
 }
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+// brokerName turns an app name like "GO SKELETON" into go-skeleton
+func brokerName(appName string) string {
+	name := strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(appName), "-"), "-")
+	if name == "" {
+		return "app"
+	}
+	return name
+}
@@ -0,0 +1,37 @@
+package config_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rahmatrdn/go-skeleton/config"
+	"github.com/rahmatrdn/go-skeleton/internal/queue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRabbitMQNamesFromAppName(t *testing.T) {
+	rabbit := config.NewRabbitMQ(context.Background(), "GO SKELETON", &config.RabbitMQOption{})
+
+	assert.Equal(t, "go-skeleton.events", rabbit.Exchange)
+	assert.Equal(t, "go-skeleton:log.insert", rabbit.QueueName(queue.ProcessSyncLog))
+	assert.Equal(t, "go-skeleton.log.insert", rabbit.RoutingKey(queue.ProcessSyncLog))
+}
+
+func TestRabbitMQNamesFromConfig(t *testing.T) {
+	rabbit := config.NewRabbitMQ(context.Background(), "GO SKELETON", &config.RabbitMQOption{
+		Exchange:         "shared",
+		QueuePrefix:      "orders",
+		RoutingKeyPrefix: "orders.v1",
+	})
+
+	assert.Equal(t, "shared", rabbit.Exchange)
+	assert.Equal(t, "orders:log.insert", rabbit.QueueName(queue.ProcessSyncLog))
+	assert.Equal(t, "orders.v1.log.insert", rabbit.RoutingKey(queue.ProcessSyncLog))
+}
+
+func TestRabbitMQNamesWithoutAppName(t *testing.T) {
+	rabbit := config.NewRabbitMQ(context.Background(), " ", &config.RabbitMQOption{})
+
+	assert.Equal(t, "app.events", rabbit.Exchange)
+	assert.Equal(t, "app:example.consumer", rabbit.QueueName(queue.ProcessExample))
+}
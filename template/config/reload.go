@@ -0,0 +1,90 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/subosito/gotenv"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// reloadableKeys are the variables of the settings ApplyReloadable applies
+var reloadableKeys = []string{"LOG_LEVEL"}
+
+// startupEnv has the reloadable variables set in the environment the process
+// started with, they keep taking precedence over .env like on startup
+var startupEnv = map[string]bool{}
+
+func init() {
+	for _, key := range reloadableKeys {
+		if _, ok := os.LookupEnv(key); ok {
+			startupEnv[key] = true
+		}
+	}
+}
+
+// ApplyReloadable applies the settings a running service picks up without a
+// restart, on startup and again on every SIGHUP:
+//
+//   - LOG_LEVEL, the level of the loggers of NewZapLog and NewConsoleLogger
+//
+// Every other setting, the ports, the database, Redis and RabbitMQ
+// connections, the auth and the CORS settings among them, is read once when
+// the service starts and needs a restart to change.
+func (c *Config) ApplyReloadable() {
+	// LoadConfig validated it, an invalid level leaves the current one
+	if level, err := zapcore.ParseLevel(c.LogLevel); err == nil {
+		logLevel.SetLevel(level)
+	}
+}
+
+// Reload reads the reloadable variables of .env again, then loads the
+// configuration and applies its reloadable settings. A variable set in the
+// environment the process started with wins over .env, as on startup. A
+// configuration that doesn't load changes nothing.
+func Reload() (*Config, error) {
+	// .env is optional, containers are usually configured through the environment
+	if env, err := gotenv.Read(".env"); err == nil {
+		for _, key := range reloadableKeys {
+			if value, ok := env[key]; ok && !startupEnv[key] {
+				os.Setenv(key, value)
+			}
+		}
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.ApplyReloadable()
+
+	return cfg, nil
+}
+
+// WatchReload calls Reload on every SIGHUP until ctx is done, so
+// kill -HUP <pid> applies an edited LOG_LEVEL. It returns once the signal is
+// caught, the reloads are logged through zapLogger.
+func WatchReload(ctx context.Context, zapLogger *zap.Logger) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(hangup)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hangup:
+				cfg, err := Reload()
+				if err != nil {
+					zapLogger.Error("config not reloaded", zap.Error(err))
+					continue
+				}
+				zapLogger.Info("config reloaded", zap.String("log_level", cfg.LogLevel))
+			}
+		}
+	}()
+}
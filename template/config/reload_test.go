@@ -0,0 +1,80 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// resetLogLevel puts the shared log level back to the default after the test
+func resetLogLevel(t *testing.T) {
+	t.Cleanup(func() { (&config.Config{LogLevel: "debug"}).ApplyReloadable() })
+}
+
+func TestSIGHUPReloadsLogLevel(t *testing.T) {
+	setValidEnv(t)
+	resetLogLevel(t)
+	t.Setenv("LOG_LEVEL", "debug")
+
+	cfg, err := config.LoadConfig()
+	require.NoError(t, err)
+	cfg.ApplyReloadable()
+
+	zapLogger, err := config.NewDevelopmentLogger()
+	require.NoError(t, err)
+	require.True(t, zapLogger.Core().Enabled(zap.DebugLevel))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	config.WatchReload(ctx, zap.NewNop())
+
+	t.Setenv("LOG_LEVEL", "warn")
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(syscall.SIGHUP))
+
+	assert.Eventually(t, func() bool {
+		return !zapLogger.Core().Enabled(zap.InfoLevel)
+	}, time.Second, 10*time.Millisecond, "SIGHUP should raise the level to warn")
+	assert.True(t, zapLogger.Core().Enabled(zap.WarnLevel))
+}
+
+func TestReloadKeepsLevelOfInvalidConfig(t *testing.T) {
+	setValidEnv(t)
+	resetLogLevel(t)
+	t.Setenv("LOG_LEVEL", "info")
+	_, err := config.Reload()
+	require.NoError(t, err)
+
+	t.Setenv("LOG_LEVEL", "loud")
+	_, err = config.Reload()
+	assert.ErrorContains(t, err, `LOG_LEVEL "loud" is not debug, info, warn or error`)
+
+	zapLogger, err := config.NewConsoleLogger()
+	require.NoError(t, err)
+	assert.False(t, zapLogger.Core().Enabled(zap.DebugLevel))
+	assert.True(t, zapLogger.Core().Enabled(zap.InfoLevel))
+}
+
+func TestReloadReadsOnlyReloadableKeysOfDotEnv(t *testing.T) {
+	setValidEnv(t)
+	resetLogLevel(t)
+	t.Setenv("LOG_LEVEL", "info")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("LOG_LEVEL=warn\nAPI_PORT=9999\n"), 0o644))
+	t.Chdir(dir)
+
+	cfg, err := config.Reload()
+	require.NoError(t, err)
+	assert.Equal(t, "warn", cfg.LogLevel)
+	assert.Equal(t, "8760", os.Getenv("API_PORT"), "a setting read once on startup isn't rewritten")
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// NewSQL opens the SQL database of the project with the GORM logger of its
+// driver, the statements are logged through zapLogger when DB_TRACE is on and
+// zapLogger isn't nil. PostgreSQL and CockroachDB projects are generated with
+// the PostgreSQL block in place of the MySQL one.
+func NewSQL(cfg *Config, zapLogger *zap.Logger) (*gorm.DB, error) {
+	// MySQL/MariaDB
+	gormLogger := NewGormTraceLogger(cfg, NewGormLogMysqlConfig(&cfg.MysqlOption), zapLogger)
+	mysqlDB, err := NewMysql(cfg.AppEnv, &cfg.MysqlOption, gormLogger)
+	if err != nil {
+		return nil, err
+	}
+	return mysqlDB.DB, nil
+
+	// PostgreSQL/CockroachDB
+	// gormLogger := NewGormTraceLogger(cfg, NewGormLogPostgreConfig(&cfg.PostgreSqlOption), zapLogger)
+	// postgreDB, err := NewPostgreSQL(cfg.AppEnv, &cfg.PostgreSqlOption, gormLogger)
+	// if err != nil {
+	// 	return nil, err
+	// }
+	// return postgreDB.DB, nil
+}
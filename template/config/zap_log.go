@@ -12,6 +12,15 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
+// LogClock dates the file of NewProductionLogger. config can't import
+// internal/helper, which imports it, so helper sets it to helper.Now and the
+// file follows APP_TIMEZONE and SetClock.
+var LogClock = time.Now
+
+// logLevel is the level of every logger of this file, LOG_LEVEL sets it on
+// startup and on SIGHUP, see ApplyReloadable
+var logLevel = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+
 func NewZapLog(env string) (*zap.Logger, error) {
 	if env == entity.PRODUCTION_ENV && os.Getenv("DEBUG_MODE") == "false" {
 		return NewProductionLogger()
@@ -32,6 +41,23 @@ func NewDevelopmentLogger() (*zap.Logger, error) {
 	config.EncoderConfig.TimeKey = "timestamp"
 	config.DisableStacktrace = true           // Disable stack trace but keep caller info
 	config.EncoderConfig.CallerKey = "caller" // Enable caller info for error location
+	config.Level = logLevel
+	return config.Build()
+}
+
+// NewConsoleLogger initializes and returns a zap.Logger writing to stdout.
+//
+// It uses the same development encoding as NewDevelopmentLogger but sends
+// every entry to stdout, which suits small services whose logs are collected
+// from the container output instead of log files.
+func NewConsoleLogger() (*zap.Logger, error) {
+	config := zap.NewDevelopmentConfig()
+	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder // ISO8601 time format
+	config.EncoderConfig.TimeKey = "timestamp"
+	config.DisableStacktrace = true
+	config.OutputPaths = []string{"stdout"}
+	config.ErrorOutputPaths = []string{"stdout"}
+	config.Level = logLevel
 	return config.Build()
 }
 
@@ -44,14 +70,14 @@ func NewDevelopmentLogger() (*zap.Logger, error) {
 //	storage/log/2025/07/2025-07-01.log
 //
 // The function ensures that the necessary directories are created before writing,
-// and logs are appended if the file already exists. The logger uses the DebugLevel
-// by default, and includes caller information in each log entry for easier tracing.
+// and logs are appended if the file already exists. The logger uses LOG_LEVEL,
+// debug by default, and includes caller information in each log entry for easier tracing.
 func NewProductionLogger() (*zap.Logger, error) {
 	config := zap.NewProductionConfig()
 	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder // ISO8601 time format
 	config.EncoderConfig.TimeKey = "timestamp"
 
-	now := time.Now()
+	now := LogClock()
 	yearMonth := now.Format("2006/01") // "2025/07"
 	date := now.Format("2006-01-02")   // "2025-07-01"
 	folderPath := filepath.Join("storage/log", yearMonth)
@@ -70,9 +96,7 @@ func NewProductionLogger() (*zap.Logger, error) {
 	fileEncoder := zapcore.NewJSONEncoder(config.EncoderConfig)
 	writer := zapcore.AddSync(logFile)
 
-	defaultLogLevel := zapcore.DebugLevel
-
-	core := zapcore.NewCore(fileEncoder, writer, defaultLogLevel)
+	core := zapcore.NewCore(fileEncoder, writer, logLevel)
 
 	logger := zap.New(core, zap.AddCaller())
 
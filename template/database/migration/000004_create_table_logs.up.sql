@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS `logs` (
+	`id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+	`status` VARCHAR(20) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`message` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`func_name` VARCHAR(200) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`error_message` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`process_name` VARCHAR(200) NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`log_fields` TEXT NULL DEFAULT NULL COLLATE 'utf8mb4_general_ci',
+	`exec_time` INT(11) NOT NULL DEFAULT 0,
+	`created` TIMESTAMP NULL DEFAULT NULL,
+	PRIMARY KEY (`id`) USING BTREE,
+	INDEX `IDX_logs_status` (`status`) USING BTREE,
+	INDEX `IDX_logs_created` (`created`) USING BTREE
+)
+COLLATE='utf8mb4_general_ci'
+ENGINE=InnoDB
+;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS users (
+	id INT8 NOT NULL DEFAULT unique_rowid(),
+	email VARCHAR(255) NULL DEFAULT NULL,
+	phone VARCHAR(16) NULL DEFAULT NULL,
+	name VARCHAR(255) NULL DEFAULT NULL,
+	role INT2 NOT NULL,
+	password VARCHAR(200) NULL DEFAULT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT current_timestamp(),
+	updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp(),
+	PRIMARY KEY (id),
+	INDEX email_k (email),
+	INDEX phone_k (phone),
+	INDEX source_k (role)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS todo_lists;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS todo_lists (
+	id INT8 NOT NULL DEFAULT unique_rowid(),
+	user_id INT8 NULL DEFAULT NULL,
+	title VARCHAR(200) NULL DEFAULT NULL,
+	description STRING NULL DEFAULT NULL,
+	doing_at DATE NULL DEFAULT NULL,
+	created_at TIMESTAMP NULL DEFAULT NULL,
+	updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+	PRIMARY KEY (id),
+	INDEX fk_todo_lists_users (user_id),
+	CONSTRAINT fk_todo_lists_users FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE NO ACTION ON DELETE NO ACTION
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS logs (
+	id INT8 NOT NULL DEFAULT unique_rowid(),
+	status VARCHAR(20) NULL DEFAULT NULL,
+	message STRING NULL DEFAULT NULL,
+	func_name VARCHAR(200) NULL DEFAULT NULL,
+	error_message STRING NULL DEFAULT NULL,
+	process_name VARCHAR(200) NULL DEFAULT NULL,
+	log_fields STRING NULL DEFAULT NULL,
+	exec_time INT8 NOT NULL DEFAULT 0,
+	created TIMESTAMP NULL DEFAULT NULL,
+	PRIMARY KEY (id),
+	INDEX idx_logs_status (status),
+	INDEX idx_logs_created (created)
+);
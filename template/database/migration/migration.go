@@ -0,0 +1,10 @@
+// Package migration embeds the migrations so the binary knows the latest
+// version without the SQL files being shipped next to it
+package migration
+
+import "embed"
+
+// Files holds the up and down migrations of this directory
+//
+//go:embed *.sql
+var Files embed.FS
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS users (
+	id BIGSERIAL NOT NULL,
+	email VARCHAR(255) NULL DEFAULT NULL,
+	phone VARCHAR(16) NULL DEFAULT NULL,
+	name VARCHAR(255) NULL DEFAULT NULL,
+	role SMALLINT NOT NULL,
+	password VARCHAR(200) NULL DEFAULT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+	updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY (id)
+);
+COMMENT ON COLUMN users.role IS 'Role user (ex. 1: Admin, 2: User Guest, etc.)';
+COMMENT ON COLUMN users.password IS 'User password hash with Bcrypt';
+CREATE INDEX IF NOT EXISTS email_k ON users (email);
+CREATE INDEX IF NOT EXISTS phone_k ON users (phone);
+CREATE INDEX IF NOT EXISTS source_k ON users (role);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS todo_lists (
+	id BIGSERIAL NOT NULL,
+	user_id BIGINT NULL DEFAULT NULL,
+	title VARCHAR(200) NULL DEFAULT NULL,
+	description TEXT NULL DEFAULT NULL,
+	doing_at DATE NULL DEFAULT NULL,
+	created_at TIMESTAMP NULL DEFAULT NULL,
+	updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_todo_lists_users FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+CREATE INDEX IF NOT EXISTS idx_todo_lists_user_id ON todo_lists (user_id);
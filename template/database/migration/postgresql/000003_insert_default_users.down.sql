@@ -0,0 +1 @@
+DELETE FROM users WHERE email = 'admin@admin.com';
\ No newline at end of file
@@ -0,0 +1,16 @@
+INSERT INTO
+    users (
+        email,
+        phone,
+        name,
+        role,
+        password
+    )
+VALUES
+    (
+        'admin@admin.com',
+        '08734123124213',
+        'Admin',
+        1,
+        '$2a$10$LVeec3QGFBhZgoUB0mAnYuf1r.B113afseQIWNSLAQR9HIkGyF8Vy' -- value: asdasd
+    );
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS logs (
+	id BIGSERIAL NOT NULL,
+	status VARCHAR(20) NULL DEFAULT NULL,
+	message TEXT NULL DEFAULT NULL,
+	func_name VARCHAR(200) NULL DEFAULT NULL,
+	error_message TEXT NULL DEFAULT NULL,
+	process_name VARCHAR(200) NULL DEFAULT NULL,
+	log_fields TEXT NULL DEFAULT NULL,
+	exec_time BIGINT NOT NULL DEFAULT 0,
+	created TIMESTAMP NULL DEFAULT NULL,
+	PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_logs_status ON logs (status);
+CREATE INDEX IF NOT EXISTS idx_logs_created ON logs (created);
@@ -0,0 +1,16 @@
+terraform {
+  required_version = ">= 1.5"
+
+  # TODO: declare the provider of your cloud, e.g.
+  # required_providers {
+  #   aws = {
+  #     source  = "hashicorp/aws"
+  #     version = "~> 5.0"
+  #   }
+  # }
+}
+
+# TODO: configure it, e.g.
+# provider "aws" {
+#   region = var.region
+# }
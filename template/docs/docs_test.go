@@ -0,0 +1,62 @@
+package docs_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rahmatrdn/go-skeleton/docs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type operation struct {
+	Security []map[string][]string `json:"security"`
+}
+
+type spec struct {
+	SecurityDefinitions map[string]struct {
+		Type string `json:"type"`
+		In   string `json:"in"`
+		Name string `json:"name"`
+	} `json:"securityDefinitions"`
+	Paths map[string]map[string]operation `json:"paths"`
+}
+
+func readSpec(t *testing.T) spec {
+	var s spec
+	require.NoError(t, json.Unmarshal([]byte(docs.SwaggerInfo.ReadDoc()), &s))
+	return s
+}
+
+func TestBearerSecurityScheme(t *testing.T) {
+	scheme, ok := readSpec(t).SecurityDefinitions["Bearer"]
+	require.True(t, ok, "the spec should define the Bearer security scheme")
+	assert.Equal(t, "apiKey", scheme.Type)
+	assert.Equal(t, "header", scheme.In)
+	assert.Equal(t, "Authorization", scheme.Name)
+}
+
+// The routes behind middleware.VerifyJWTToken need the Bearer scheme for the
+// Authorize button of the Swagger UI to send the token
+func TestProtectedRoutesUseBearer(t *testing.T) {
+	paths := readSpec(t).Paths
+
+	protected := map[string][]string{
+		"/api/v1/auth/check-token":   {"get"},
+		"/api/v1/auth/refresh-token": {"get"},
+		"/api/v1/todo-lists":         {"get", "post"},
+		"/api/v1/todo-lists/{id}":    {"get", "put", "delete"},
+	}
+	for path, methods := range protected {
+		for _, method := range methods {
+			op, ok := paths[path][method]
+			if assert.True(t, ok, "%s %s is not documented", method, path) {
+				assert.Equal(t, []map[string][]string{{"Bearer": {}}}, op.Security, "%s %s", method, path)
+			}
+		}
+	}
+
+	for _, path := range []string{"/api/v1/auth/login", "/api/v1/auth/register"} {
+		assert.Empty(t, paths[path]["post"].Security, "%s should be open", path)
+	}
+}
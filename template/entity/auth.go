@@ -40,6 +40,15 @@ type CreateUserReq struct {
 	Phone           string `json:"phone" validate:"required" name:"Nomor Telepon"`
 	RoleAccess      int8   `json:"role_access" validate:"required" name:"Hak Akses"`
 }
+
+// BootstrapAdminReq is the first admin of a fresh deploy, created by
+// cmd/bootstrap
+type BootstrapAdminReq struct {
+	Name     string
+	Email    string
+	Password string
+}
+
 type CreateUserResponse struct {
 	UserID     int64  `json:"user_id"`
 	Name       string `json:"name"`
@@ -1,6 +1,11 @@
 package entity
 
-import "encoding/json"
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
 
 const (
 	GeneralLogFilePath = "/storage/log/general"
@@ -14,6 +19,7 @@ type Log struct {
 	Process      string        `json:"process"`
 	Status       LogType       `json:"status"`
 	LogFields    CaptureFields `json:"capture_fields"`
+	RequestID    string        `json:"request_id,omitempty"`
 }
 
 type LogType string
@@ -28,12 +34,117 @@ const (
 
 type CaptureFields map[string]string
 
+// ErrMalformedPayload is wrapped by the errors of queue payloads that can never
+// be processed, so the consumer drops them instead of retrying
+var ErrMalformedPayload = errors.New("malformed payload")
+
+// InvalidLogError maps the malformed keys of a log payload to what is wrong with them
+type InvalidLogError map[string]string
+
+func (e InvalidLogError) Error() string {
+	keys := make([]string, 0, len(e))
+	for key := range e {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	problems := make([]string, 0, len(keys))
+	for _, key := range keys {
+		problems = append(problems, fmt.Sprintf("%s: %s", key, e[key]))
+	}
+
+	return fmt.Sprintf("%s: %s", ErrMalformedPayload.Error(), strings.Join(problems, ", "))
+}
+
+func (e InvalidLogError) Unwrap() error {
+	return ErrMalformedPayload
+}
+
+func (t LogType) valid() bool {
+	switch t {
+	case LogSuccess, LogError, LogInfo, LogWarning, LogDebug:
+		return true
+	}
+	return false
+}
+
+// LoadFromMap loads a log published to the queue. Missing keys are left empty,
+// except status which is required. Malformed keys are left empty too and
+// returned in an InvalidLogError.
 func (c *Log) LoadFromMap(m map[string]interface{}) error {
-	data, err := json.Marshal(m)
-	if err == nil {
-		err = json.Unmarshal(data, c)
+	invalid := InvalidLogError{}
+
+	loadString := func(key string, dest *string) {
+		value, ok := m[key]
+		if !ok || value == nil {
+			return
+		}
+		if str, ok := value.(string); ok {
+			*dest = str
+			return
+		}
+		invalid[key] = fmt.Sprintf("expected a string, got %T", value)
+	}
+
+	loadString("func_name", &c.FuncName)
+	loadString("message", &c.Message)
+	loadString("error_message", &c.ErrorMessage)
+	loadString("process", &c.Process)
+	loadString("request_id", &c.RequestID)
+
+	var status string
+	loadString("status", &status)
+	if _, ok := invalid["status"]; !ok {
+		switch {
+		case status == "":
+			invalid["status"] = "missing"
+		case !LogType(status).valid():
+			invalid["status"] = fmt.Sprintf("unknown status %q", status)
+		default:
+			c.Status = LogType(status)
+		}
+	}
+
+	if fields, err := loadCaptureFields(m["capture_fields"]); err != "" {
+		invalid["capture_fields"] = err
+	} else {
+		c.LogFields = fields
+	}
+
+	if len(invalid) > 0 {
+		return invalid
+	}
+	return nil
+}
+
+// loadCaptureFields accepts a decoded JSON object of strings
+func loadCaptureFields(value interface{}) (CaptureFields, string) {
+	switch value := value.(type) {
+	case nil:
+		return nil, ""
+	case CaptureFields:
+		return value, ""
+	case map[string]string:
+		return value, ""
+	case map[string]interface{}:
+		fields := CaptureFields{}
+		malformed := []string{}
+		for key, field := range value {
+			str, ok := field.(string)
+			if !ok {
+				malformed = append(malformed, key)
+				continue
+			}
+			fields[key] = str
+		}
+		if len(malformed) > 0 {
+			sort.Strings(malformed)
+			return nil, fmt.Sprintf("expected strings for %s", strings.Join(malformed, ", "))
+		}
+		return fields, ""
+	default:
+		return nil, fmt.Sprintf("expected an object, got %T", value)
 	}
-	return err
 }
 
 const (
@@ -0,0 +1,96 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFromMap(t *testing.T) {
+	var log entity.Log
+	err := log.LoadFromMap(map[string]interface{}{
+		"func_name":      "OrderUsecase.Create",
+		"message":        "Order failed",
+		"error_message":  "stock is empty",
+		"process":        "checkout",
+		"status":         "ERROR",
+		"capture_fields": map[string]interface{}{"order_id": "12", "execution_time": "120"},
+		"unknown":        true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.Log{
+		FuncName:     "OrderUsecase.Create",
+		Message:      "Order failed",
+		ErrorMessage: "stock is empty",
+		Process:      "checkout",
+		Status:       entity.LogError,
+		LogFields:    entity.CaptureFields{"order_id": "12", "execution_time": "120"},
+	}, log)
+}
+
+func TestLoadFromMapDefaultsMissingKeys(t *testing.T) {
+	var log entity.Log
+	err := log.LoadFromMap(map[string]interface{}{"status": "INFO", "message": nil})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.Log{Status: entity.LogInfo}, log)
+}
+
+func TestLoadFromMapMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload map[string]interface{}
+		invalid entity.InvalidLogError
+		loaded  entity.Log
+	}{
+		{
+			name:    "empty payload",
+			payload: nil,
+			invalid: entity.InvalidLogError{"status": "missing"},
+		},
+		{
+			name:    "unknown status",
+			payload: map[string]interface{}{"status": "FATAL", "message": "Order failed"},
+			invalid: entity.InvalidLogError{"status": `unknown status "FATAL"`},
+			loaded:  entity.Log{Message: "Order failed"},
+		},
+		{
+			name:    "wrong typed strings",
+			payload: map[string]interface{}{"status": "INFO", "func_name": json.Number("42"), "process": []interface{}{"checkout"}},
+			invalid: entity.InvalidLogError{"func_name": "expected a string, got json.Number", "process": "expected a string, got []interface {}"},
+			loaded:  entity.Log{Status: entity.LogInfo},
+		},
+		{
+			name:    "capture fields not an object",
+			payload: map[string]interface{}{"status": "INFO", "capture_fields": "order_id=12"},
+			invalid: entity.InvalidLogError{"capture_fields": "expected an object, got string"},
+			loaded:  entity.Log{Status: entity.LogInfo},
+		},
+		{
+			name:    "capture fields with non string values",
+			payload: map[string]interface{}{"status": "INFO", "capture_fields": map[string]interface{}{"order_id": "12", "execution_time": json.Number("120"), "retried": true}},
+			invalid: entity.InvalidLogError{"capture_fields": "expected strings for execution_time, retried"},
+			loaded:  entity.Log{Status: entity.LogInfo},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var log entity.Log
+			err := log.LoadFromMap(tt.payload)
+
+			assert.ErrorIs(t, err, entity.ErrMalformedPayload)
+			assert.Equal(t, tt.invalid, err)
+			assert.Equal(t, tt.loaded, log)
+		})
+	}
+}
+
+func TestInvalidLogErrorMessage(t *testing.T) {
+	err := entity.InvalidLogError{"status": "missing", "func_name": "expected a string, got bool"}
+
+	assert.Equal(t, "malformed payload: func_name: expected a string, got bool, status: missing", err.Error())
+}
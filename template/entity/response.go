@@ -9,14 +9,32 @@ const (
 	INVALID_PAYLOAD_MSG  = "Invalid Payload Request Data"
 	INVALID_TOKEN_CODE   = "05"
 	INVALID_TOKEN_MSG    = "Invalid Access Token"
+	INVALID_API_KEY_CODE = "06"
+	INVALID_API_KEY_MSG  = "Invalid API Key"
+	MISSING_SCOPE_CODE   = "07"
+	MISSING_SCOPE_MSG    = "API Key is missing a required scope"
 	BAD_REQUEST_CODE     = "30"
 	BAD_REQUEST_MSG      = "Bad Request"
+	INTERNAL_ERROR_CODE  = "99"
 	DATA_NOT_FOUND_MSG   = "Data not found"
 	USER_NOT_FOUND_MSG   = "User not found"
 
 	GENERAL_ERROR_MESSAGE = "Something went wrong. Please try again later."
 )
 
+// Machine-readable error_code of the error responses, one per kind of error
+// rather than per message like the numeric code
+const (
+	ERROR_CODE_BAD_REQUEST       = "BAD_REQUEST"
+	ERROR_CODE_VALIDATION_FAILED = "VALIDATION_FAILED"
+	ERROR_CODE_UNAUTHORIZED      = "UNAUTHORIZED"
+	ERROR_CODE_FORBIDDEN         = "FORBIDDEN"
+	ERROR_CODE_NOT_FOUND         = "NOT_FOUND"
+	ERROR_CODE_CONFLICT          = "CONFLICT"
+	ERROR_CODE_TOO_LARGE         = "PAYLOAD_TOO_LARGE"
+	ERROR_CODE_INTERNAL          = "INTERNAL"
+)
+
 type GeneralResponse struct {
 	Code    int         `json:"code"`
 	Message string      `json:"message"`
@@ -29,13 +47,15 @@ type ValidationError struct {
 }
 
 type CustomErrorResponse struct {
-	Message  string `json:"message,omitempty"`
-	ErrCode  string `json:"code,omitempty"`
-	HTTPCode int    `json:"http_code"`
+	Message   string `json:"message,omitempty"`
+	ErrCode   string `json:"code,omitempty"`
+	ErrorCode string `json:"error_code,omitempty" example:"NOT_FOUND"`
+	HTTPCode  int    `json:"http_code"`
 }
 type CustomErrorResponseWithMeta struct {
-	Message  string          `json:"message,omitempty"`
-	ErrCode  string          `json:"code,omitempty"`
-	HTTPCode int             `json:"http_code"`
-	Meta     []ErrorResponse `json:"meta,omitempty"`
+	Message   string          `json:"message,omitempty"`
+	ErrCode   string          `json:"code,omitempty"`
+	ErrorCode string          `json:"error_code,omitempty" example:"VALIDATION_FAILED"`
+	HTTPCode  int             `json:"http_code"`
+	Meta      []ErrorResponse `json:"meta,omitempty"`
 }
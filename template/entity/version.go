@@ -0,0 +1,9 @@
+package entity
+
+// Version is the build of the running service, reported on /version
+type Version struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+}
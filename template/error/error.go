@@ -8,83 +8,202 @@ import (
 
 func ErrRecordNotFound() CustomErrorResponse {
 	return CustomErrorResponse{
-		Message:  entity.DATA_NOT_FOUND_MSG,
-		ErrCode:  entity.BAD_REQUEST_MSG,
-		HTTPCode: http.StatusNotFound,
+		Message:   entity.DATA_NOT_FOUND_MSG,
+		ErrCode:   entity.BAD_REQUEST_MSG,
+		ErrorCode: entity.ERROR_CODE_NOT_FOUND,
+		HTTPCode:  http.StatusNotFound,
 	}
 }
 
 func ErrUserNotFound() CustomErrorResponse {
 	return CustomErrorResponse{
-		Message:  entity.USER_NOT_FOUND_MSG,
-		ErrCode:  entity.BAD_REQUEST_MSG,
-		HTTPCode: http.StatusNotFound,
+		Message:   entity.USER_NOT_FOUND_MSG,
+		ErrCode:   entity.BAD_REQUEST_MSG,
+		ErrorCode: entity.ERROR_CODE_NOT_FOUND,
+		HTTPCode:  http.StatusNotFound,
 	}
 }
 
 func ErrInvalidEmailOrPassword() CustomErrorResponse {
 	return CustomErrorResponse{
-		Message:  entity.INVALID_AUTH_MSG,
-		ErrCode:  entity.INVALID_AUTH_CODE,
-		HTTPCode: http.StatusUnauthorized,
+		Message:   entity.INVALID_AUTH_MSG,
+		ErrCode:   entity.INVALID_AUTH_CODE,
+		ErrorCode: entity.ERROR_CODE_UNAUTHORIZED,
+		HTTPCode:  http.StatusUnauthorized,
 	}
 }
 
 func ErrInvalidToken() CustomErrorResponse {
 	return CustomErrorResponse{
-		Message:  entity.INVALID_TOKEN_MSG,
-		ErrCode:  entity.INVALID_TOKEN_CODE,
-		HTTPCode: http.StatusUnauthorized,
+		Message:   entity.INVALID_TOKEN_MSG,
+		ErrCode:   entity.INVALID_TOKEN_CODE,
+		ErrorCode: entity.ERROR_CODE_UNAUTHORIZED,
+		HTTPCode:  http.StatusUnauthorized,
+	}
+}
+
+func ErrInvalidApiKey() CustomErrorResponse {
+	return CustomErrorResponse{
+		Message:   entity.INVALID_API_KEY_MSG,
+		ErrCode:   entity.INVALID_API_KEY_CODE,
+		ErrorCode: entity.ERROR_CODE_UNAUTHORIZED,
+		HTTPCode:  http.StatusUnauthorized,
+	}
+}
+
+func ErrMissingScope() CustomErrorResponse {
+	return CustomErrorResponse{
+		Message:   entity.MISSING_SCOPE_MSG,
+		ErrCode:   entity.MISSING_SCOPE_CODE,
+		ErrorCode: entity.ERROR_CODE_FORBIDDEN,
+		HTTPCode:  http.StatusForbidden,
 	}
 }
 
 func ErrInvalidPayload(meta []entity.ErrorResponse) CustomErrorResponseWithMeta {
 	return CustomErrorResponseWithMeta{
-		Message:  entity.INVALID_PAYLOAD_MSG,
-		ErrCode:  entity.INVALID_PAYLOAD_CODE,
-		HTTPCode: http.StatusUnprocessableEntity,
-		Meta:     meta,
+		Message:   entity.INVALID_PAYLOAD_MSG,
+		ErrCode:   entity.INVALID_PAYLOAD_CODE,
+		ErrorCode: entity.ERROR_CODE_VALIDATION_FAILED,
+		HTTPCode:  http.StatusUnprocessableEntity,
+		Meta:      meta,
 	}
 }
 
+// CustomErrorResponse is the error of the API. HTTPCode is the response
+// status, ErrorCode the machine-readable kind of error, ErrCode the numeric
+// code of the message and Cause, never sent to the client, what went wrong
+// underneath.
 type CustomErrorResponse struct {
-	Message  string `json:"message,omitempty"`
-	ErrCode  string `json:"code,omitempty"`
-	HTTPCode int    `json:"http_code"`
+	Message   string `json:"message,omitempty"`
+	ErrCode   string `json:"code,omitempty"`
+	ErrorCode string `json:"error_code,omitempty"`
+	HTTPCode  int    `json:"http_code"`
+	Cause     error  `json:"-"`
 }
 type CustomErrorResponseWithMeta struct {
-	Message  string                 `json:"message,omitempty"`
-	ErrCode  string                 `json:"code,omitempty"`
-	HTTPCode int                    `json:"http_code"`
-	Meta     []entity.ErrorResponse `json:"meta,omitempty"`
+	Message   string                 `json:"message,omitempty"`
+	ErrCode   string                 `json:"code,omitempty"`
+	ErrorCode string                 `json:"error_code,omitempty"`
+	HTTPCode  int                    `json:"http_code"`
+	Meta      []entity.ErrorResponse `json:"meta,omitempty"`
 }
 
 // Error is a function to convert error to string.
 // It exists to satisfy error interface
 func (c CustomErrorResponse) Error() string {
+	if c.Cause != nil {
+		return c.Message + ": " + c.Cause.Error()
+	}
 	return c.Message
 }
 
+// Unwrap returns the cause, so errors.Is and errors.As see through to it
+func (c CustomErrorResponse) Unwrap() error {
+	return c.Cause
+}
+
+// Is matches the same error whatever its cause, like
+// errors.Is(err, apperr.ErrUserNotFound())
+func (c CustomErrorResponse) Is(target error) bool {
+	t, ok := target.(CustomErrorResponse)
+	return ok && t.Message == c.Message && t.ErrCode == c.ErrCode && t.ErrorCode == c.ErrorCode && t.HTTPCode == c.HTTPCode
+}
+
+// WithCause keeps the error that led to c, for the logs
+func (c CustomErrorResponse) WithCause(cause error) CustomErrorResponse {
+	c.Cause = cause
+	return c
+}
+
 func ErrGeneralInvalid() CustomErrorResponse {
 	return CustomErrorResponse{
-		Message:  entity.GENERAL_ERROR_MESSAGE,
-		ErrCode:  entity.BAD_REQUEST_MSG,
-		HTTPCode: http.StatusUnprocessableEntity,
+		Message:   entity.GENERAL_ERROR_MESSAGE,
+		ErrCode:   entity.BAD_REQUEST_MSG,
+		ErrorCode: entity.ERROR_CODE_VALIDATION_FAILED,
+		HTTPCode:  http.StatusUnprocessableEntity,
+	}
+}
+
+// ErrInternal answers requests that failed unexpectedly, like a panicking handler
+func ErrInternal() CustomErrorResponse {
+	return CustomErrorResponse{
+		Message:   entity.GENERAL_ERROR_MESSAGE,
+		ErrCode:   entity.INTERNAL_ERROR_CODE,
+		ErrorCode: entity.ERROR_CODE_INTERNAL,
+		HTTPCode:  http.StatusInternalServerError,
 	}
 }
 
 func ErrInvalidRequest() CustomErrorResponse {
 	return CustomErrorResponse{
-		Message:  entity.INVALID_PAYLOAD_MSG,
-		ErrCode:  entity.BAD_REQUEST_MSG,
-		HTTPCode: http.StatusUnprocessableEntity,
+		Message:   entity.INVALID_PAYLOAD_MSG,
+		ErrCode:   entity.BAD_REQUEST_MSG,
+		ErrorCode: entity.ERROR_CODE_VALIDATION_FAILED,
+		HTTPCode:  http.StatusUnprocessableEntity,
+	}
+}
+
+// NotFound is a 404 for a missing resource, e.g. NotFound("Order not found")
+func NotFound(message string) CustomErrorResponse {
+	return CustomError(message, entity.BAD_REQUEST_CODE, http.StatusNotFound)
+}
+
+// Validation is a 422 for a request that is well formed but not acceptable
+func Validation(message string) CustomErrorResponse {
+	return CustomError(message, entity.INVALID_PAYLOAD_CODE, http.StatusUnprocessableEntity)
+}
+
+// Unauthorized is a 401 for a request without valid credentials
+func Unauthorized(message string) CustomErrorResponse {
+	return CustomError(message, entity.INVALID_AUTH_CODE, http.StatusUnauthorized)
+}
+
+// Forbidden is a 403 for credentials that don't allow the request
+func Forbidden(message string) CustomErrorResponse {
+	return CustomError(message, entity.MISSING_SCOPE_CODE, http.StatusForbidden)
+}
+
+// Conflict is a 409 for a request clashing with the current state, like a
+// duplicate email
+func Conflict(message string) CustomErrorResponse {
+	return CustomError(message, entity.BAD_REQUEST_CODE, http.StatusConflict)
+}
+
+// Internal is the 500 of ErrInternal keeping cause for the logs, the client
+// only gets the general message
+func Internal(cause error) CustomErrorResponse {
+	return ErrInternal().WithCause(cause)
+}
+
+// statusErrorCodes are the error_code of each status, others get the one of
+// their class
+var statusErrorCodes = map[int]string{
+	http.StatusBadRequest:            entity.ERROR_CODE_BAD_REQUEST,
+	http.StatusUnauthorized:          entity.ERROR_CODE_UNAUTHORIZED,
+	http.StatusForbidden:             entity.ERROR_CODE_FORBIDDEN,
+	http.StatusNotFound:              entity.ERROR_CODE_NOT_FOUND,
+	http.StatusConflict:              entity.ERROR_CODE_CONFLICT,
+	http.StatusRequestEntityTooLarge: entity.ERROR_CODE_TOO_LARGE,
+	http.StatusUnprocessableEntity:   entity.ERROR_CODE_VALIDATION_FAILED,
+}
+
+// ErrorCodeOf returns the error_code of an HTTP status
+func ErrorCodeOf(httpCode int) string {
+	if code, ok := statusErrorCodes[httpCode]; ok {
+		return code
+	}
+	if httpCode >= http.StatusInternalServerError {
+		return entity.ERROR_CODE_INTERNAL
 	}
+	return entity.ERROR_CODE_BAD_REQUEST
 }
 
 func CustomError(message string, errCode string, httpCode int) CustomErrorResponse {
 	return CustomErrorResponse{
-		Message:  message,
-		ErrCode:  errCode,
-		HTTPCode: httpCode,
+		Message:   message,
+		ErrCode:   errCode,
+		ErrorCode: ErrorCodeOf(httpCode),
+		HTTPCode:  httpCode,
 	}
 }
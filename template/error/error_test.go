@@ -0,0 +1,72 @@
+package error_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/rahmatrdn/go-skeleton/entity"
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructors(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       apperr.CustomErrorResponse
+		httpCode  int
+		errorCode string
+	}{
+		{"not found", apperr.NotFound("Order not found"), http.StatusNotFound, entity.ERROR_CODE_NOT_FOUND},
+		{"validation", apperr.Validation("Quantity must be positive"), http.StatusUnprocessableEntity, entity.ERROR_CODE_VALIDATION_FAILED},
+		{"unauthorized", apperr.Unauthorized("Session expired"), http.StatusUnauthorized, entity.ERROR_CODE_UNAUTHORIZED},
+		{"forbidden", apperr.Forbidden("Admins only"), http.StatusForbidden, entity.ERROR_CODE_FORBIDDEN},
+		{"conflict", apperr.Conflict("Email already used"), http.StatusConflict, entity.ERROR_CODE_CONFLICT},
+		{"internal", apperr.Internal(errors.New("connection refused")), http.StatusInternalServerError, entity.ERROR_CODE_INTERNAL},
+		{"record not found", apperr.ErrRecordNotFound(), http.StatusNotFound, entity.ERROR_CODE_NOT_FOUND},
+		{"invalid token", apperr.ErrInvalidToken(), http.StatusUnauthorized, entity.ERROR_CODE_UNAUTHORIZED},
+		{"missing scope", apperr.ErrMissingScope(), http.StatusForbidden, entity.ERROR_CODE_FORBIDDEN},
+		{"invalid request", apperr.ErrInvalidRequest(), http.StatusUnprocessableEntity, entity.ERROR_CODE_VALIDATION_FAILED},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.httpCode, tt.err.HTTPCode)
+			assert.Equal(t, tt.errorCode, tt.err.ErrorCode)
+		})
+	}
+}
+
+func TestEnvelope(t *testing.T) {
+	body, err := json.Marshal(apperr.Conflict("Email already used"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"message":"Email already used","code":"30","error_code":"CONFLICT","http_code":409}`, string(body))
+
+	// The cause stays out of the response
+	body, err = json.Marshal(apperr.Internal(errors.New("dial tcp: connection refused")))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"message":"Something went wrong. Please try again later.","code":"99","error_code":"INTERNAL","http_code":500}`, string(body))
+}
+
+func TestCause(t *testing.T) {
+	cause := errors.New("dial tcp: connection refused")
+	err := fmt.Errorf("get user: %w", apperr.ErrUserNotFound().WithCause(cause))
+
+	assert.ErrorIs(t, err, apperr.ErrUserNotFound())
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, apperr.ErrRecordNotFound())
+	assert.EqualError(t, err, "get user: "+entity.USER_NOT_FOUND_MSG+": dial tcp: connection refused")
+
+	var appErr apperr.CustomErrorResponse
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.HTTPCode)
+}
+
+func TestErrorCodeOf(t *testing.T) {
+	assert.Equal(t, entity.ERROR_CODE_TOO_LARGE, apperr.ErrorCodeOf(http.StatusRequestEntityTooLarge))
+	assert.Equal(t, entity.ERROR_CODE_BAD_REQUEST, apperr.ErrorCodeOf(http.StatusMethodNotAllowed))
+	assert.Equal(t, entity.ERROR_CODE_INTERNAL, apperr.ErrorCodeOf(http.StatusBadGateway))
+}
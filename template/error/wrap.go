@@ -0,0 +1,68 @@
+package error
+
+import (
+	"errors"
+	"fmt"
+
+	errwrap "github.com/pkg/errors"
+)
+
+// stackTracer is an error of pkg/errors holding the stack it was created at
+type stackTracer interface {
+	StackTrace() errwrap.StackTrace
+}
+
+// Wrap adds message, usually the funcName of the layer, in front of err:
+//
+//	return nil, apperr.Wrap(err, "TodoListRepository.GetByID")
+//
+// The stack is captured by the first Wrap of the chain, the ones above only
+// add their message. errors.Is and errors.As still see err. Wrap returns nil
+// when err is nil.
+func Wrap(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	if hasStack(err) {
+		return errwrap.WithMessage(err, message)
+	}
+	return errwrap.Wrap(err, message)
+}
+
+// Wrapf is Wrap with a formatted message
+func Wrapf(err error, format string, args ...any) error {
+	return Wrap(err, fmt.Sprintf(format, args...))
+}
+
+// RootCause returns the error the chain of err started from, like the driver's
+// error under the messages of the repository and usecase
+func RootCause(err error) error {
+	for err != nil {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+	return nil
+}
+
+// StackTrace returns the stack captured by Wrap, formatted with %+v one frame
+// per function and file:line, or "" when err wasn't wrapped
+func StackTrace(err error) string {
+	var stack errwrap.StackTrace
+	for ; err != nil; err = errors.Unwrap(err) {
+		if tracer, ok := err.(stackTracer); ok {
+			stack = tracer.StackTrace()
+		}
+	}
+	if stack == nil {
+		return ""
+	}
+	return fmt.Sprintf("%+v", stack)
+}
+
+func hasStack(err error) bool {
+	var tracer stackTracer
+	return errors.As(err, &tracer)
+}
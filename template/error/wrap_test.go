@@ -0,0 +1,47 @@
+package error_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+	"github.com/stretchr/testify/assert"
+)
+
+var errConnRefused = errors.New("dial tcp: connection refused")
+
+func getTodoList() error {
+	return apperr.Wrap(errConnRefused, "TodoListRepository.GetByID")
+}
+
+func TestWrap(t *testing.T) {
+	err := apperr.Wrap(getTodoList(), "CrudTodoListUsecase.GetByID")
+
+	assert.EqualError(t, err, "CrudTodoListUsecase.GetByID: TodoListRepository.GetByID: dial tcp: connection refused")
+	assert.ErrorIs(t, err, errConnRefused)
+	assert.Equal(t, errConnRefused, apperr.RootCause(err))
+
+	// The stack is the one of the first Wrap, where the error happened
+	stack := apperr.StackTrace(err)
+	assert.Contains(t, stack, "error_test.getTodoList")
+	assert.Contains(t, stack, "wrap_test.go")
+}
+
+func TestWrapKeepsAppError(t *testing.T) {
+	err := apperr.Wrapf(apperr.ErrUserNotFound(), "UserRepository.GetByEmail %d", 1)
+
+	assert.ErrorIs(t, err, apperr.ErrUserNotFound())
+	var appErr apperr.CustomErrorResponse
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperr.ErrUserNotFound(), apperr.RootCause(err))
+}
+
+func TestWrapNil(t *testing.T) {
+	assert.NoError(t, apperr.Wrap(nil, "TodoListRepository.GetByID"))
+	assert.Nil(t, apperr.RootCause(nil))
+}
+
+func TestStackTraceWithoutWrap(t *testing.T) {
+	assert.Empty(t, apperr.StackTrace(fmt.Errorf("get user: %w", errConnRefused)))
+}
@@ -0,0 +1,90 @@
+package filter
+
+import (
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var mongoOps = map[Op]string{
+	Eq:  "$eq",
+	Ne:  "$ne",
+	Gt:  "$gt",
+	Gte: "$gte",
+	Lt:  "$lt",
+	Lte: "$lte",
+}
+
+// Bson returns the filter as a MongoDB query document, e.g. for
+// collection.Find(ctx, f.Bson())
+func (f *Filter) Bson() bson.M {
+	query := bson.M{}
+
+	for _, condition := range f.Conditions {
+		// Conditions on the same field are merged, e.g. {"$gte": from, "$lt": to}
+		ops, ok := query[condition.Column].(bson.M)
+		if !ok {
+			ops = bson.M{}
+			query[condition.Column] = ops
+		}
+		ops[mongoOps[condition.Op]] = condition.Value
+	}
+
+	if f.Search != "" {
+		pattern := regexp.QuoteMeta(f.Search)
+
+		matches := bson.A{}
+		for _, column := range f.SearchColumns {
+			matches = append(matches, bson.M{column: bson.M{"$regex": pattern, "$options": "i"}})
+		}
+		query["$or"] = matches
+	}
+
+	return query
+}
+
+// Bson returns the condition of a cursor page, matching the items after the
+// After values. It's empty for the first page and OffsetPages. Both Filter.Bson
+// and it may use $or, combine them with $and:
+//
+//	collection.Find(ctx, bson.M{"$and": bson.A{f.Bson(), page.Bson()}}, page.FindOptions())
+func (p *Page) Bson() bson.M {
+	if p.Mode != CursorPages || p.After == nil {
+		return bson.M{}
+	}
+
+	op := "$gt"
+	if p.Desc {
+		op = "$lt"
+	}
+	after := bson.A{}
+	for i, key := range p.Keys {
+		condition := bson.M{}
+		for j := 0; j < i; j++ {
+			condition[p.Keys[j].Column] = p.After[j]
+		}
+		condition[key.Column] = bson.M{op: p.After[i]}
+		after = append(after, condition)
+	}
+	return bson.M{"$or": after}
+}
+
+// FindOptions sorts and limits a Find to the page, fetching Limit+1 documents,
+// see Paginate
+func (p *Page) FindOptions() *options.FindOptions {
+	direction := 1
+	if p.Desc {
+		direction = -1
+	}
+	sort := bson.D{}
+	for _, key := range p.Keys {
+		sort = append(sort, bson.E{Key: key.Column, Value: direction})
+	}
+
+	opts := options.Find().SetSort(sort).SetLimit(int64(p.Limit + 1))
+	if p.Mode == OffsetPages {
+		opts.SetSkip(int64(p.Offset()))
+	}
+	return opts
+}
@@ -0,0 +1,60 @@
+package filter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/internal/filter"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestBson(t *testing.T) {
+	f, err := filter.Parse(map[string]string{
+		"user_id":       "3",
+		"doing_at[gte]": "2024-01-01",
+		"doing_at[lt]":  "2024-02-01",
+		"search":        "milk (2L)",
+	}, todoListFields)
+	assert.NoError(t, err)
+
+	assert.Equal(t, bson.M{
+		"user_id":  bson.M{"$eq": int64(3)},
+		"doing_at": bson.M{"$gte": f.Conditions[0].Value, "$lt": f.Conditions[1].Value},
+		"$or": bson.A{
+			bson.M{"description": bson.M{"$regex": `milk \(2L\)`, "$options": "i"}},
+			bson.M{"title": bson.M{"$regex": `milk \(2L\)`, "$options": "i"}},
+		},
+	}, f.Bson())
+}
+
+func TestPageBson(t *testing.T) {
+	cursor := filter.EncodeCursor(time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), int64(5))
+	page, err := filter.ParsePage(map[string]string{"limit": "2", "cursor": cursor}, newestTodoLists)
+	assert.NoError(t, err)
+
+	assert.Equal(t, bson.M{"$or": bson.A{
+		bson.M{"created_at": bson.M{"$lt": page.After[0]}},
+		bson.M{"created_at": page.After[0], "id": bson.M{"$lt": int64(5)}},
+	}}, page.Bson())
+
+	opts := page.FindOptions()
+	assert.Equal(t, bson.D{{Key: "created_at", Value: -1}, {Key: "id", Value: -1}}, opts.Sort)
+	assert.Equal(t, int64(3), *opts.Limit)
+	assert.Nil(t, opts.Skip)
+
+	first, err := filter.ParsePage(map[string]string{}, newestTodoLists)
+	assert.NoError(t, err)
+	assert.Empty(t, first.Bson())
+}
+
+func TestOffsetPageFindOptions(t *testing.T) {
+	page, err := filter.ParsePage(map[string]string{"page": "3", "limit": "10"}, filter.Paging{Keys: []filter.SortKey{{Column: "_id"}}})
+	assert.NoError(t, err)
+
+	opts := page.FindOptions()
+	assert.Equal(t, bson.D{{Key: "_id", Value: 1}}, opts.Sort)
+	assert.Equal(t, int64(11), *opts.Limit)
+	assert.Equal(t, int64(20), *opts.Skip)
+	assert.Empty(t, page.Bson())
+}
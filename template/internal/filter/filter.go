@@ -0,0 +1,166 @@
+package filter
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/entity"
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
+)
+
+// SearchParam is the query param matched against every Searchable field
+const SearchParam = "search"
+
+type Kind int
+
+const (
+	String Kind = iota
+	Int
+	Bool
+	// Time accepts RFC 3339 timestamps and 2006-01-02 dates
+	Time
+)
+
+type Op string
+
+const (
+	Eq  Op = "eq"
+	Ne  Op = "ne"
+	Gt  Op = "gt"
+	Gte Op = "gte"
+	Lt  Op = "lt"
+	Lte Op = "lte"
+)
+
+// Field allow-lists a query param. Only the Column of an allowed param ever
+// reaches the query, the param name itself doesn't.
+type Field struct {
+	Column string
+	Kind   Kind
+	// Range allows the gt, gte, lt and lte operators besides eq and ne
+	Range bool
+	// Searchable includes the field in the SearchParam match, String fields only
+	Searchable bool
+}
+
+// Fields maps the query param names of a list endpoint to their Field
+type Fields map[string]Field
+
+type Condition struct {
+	Column string
+	Op     Op
+	Value  interface{}
+}
+
+// Filter is the parsed filter of a list request, turned into a query by
+// Filter.Scope for GORM or Filter.Bson for MongoDB
+type Filter struct {
+	Conditions []Condition
+	Search     string
+	// SearchColumns are the Searchable columns Search is matched against
+	SearchColumns []string
+}
+
+// Parse reads query params like ?status=done&created_at[gte]=2024-01-01&search=milk.
+// Params missing from fields are rejected, except the ignored ones (e.g. the
+// PageParams of ParsePage or sort params handled elsewhere).
+//
+//	f, err := filter.Parse(c.Queries(), fields, filter.PageParams...)
+func Parse(query map[string]string, fields Fields, ignored ...string) (*Filter, error) {
+	f := &Filter{}
+
+	params := make([]string, 0, len(query))
+	for param := range query {
+		params = append(params, param)
+	}
+	// Keep the conditions in a stable order, the map order is random
+	sort.Strings(params)
+
+	for _, param := range params {
+		value := query[param]
+		if slices.Contains(ignored, param) || value == "" {
+			continue
+		}
+
+		if param == SearchParam {
+			f.Search = value
+			continue
+		}
+
+		name, op := param, Eq
+		if open := strings.Index(param, "["); open > 0 && strings.HasSuffix(param, "]") {
+			name, op = param[:open], Op(param[open+1:len(param)-1])
+		}
+
+		field, ok := fields[name]
+		if !ok {
+			return nil, errInvalidFilter(fmt.Sprintf("Filtering on %s is not allowed", name))
+		}
+
+		switch op {
+		case Eq, Ne:
+		case Gt, Gte, Lt, Lte:
+			if !field.Range {
+				return nil, errInvalidFilter(fmt.Sprintf("%s does not support the %s operator", name, op))
+			}
+		default:
+			return nil, errInvalidFilter(fmt.Sprintf("Unknown filter operator %s", op))
+		}
+
+		typed, err := field.parse(value)
+		if err != nil {
+			return nil, errInvalidFilter(fmt.Sprintf("Invalid value for %s: %s", name, value))
+		}
+
+		f.Conditions = append(f.Conditions, Condition{Column: field.column(name), Op: op, Value: typed})
+	}
+
+	if f.Search != "" {
+		for name, field := range fields {
+			if field.Searchable && field.Kind == String {
+				f.SearchColumns = append(f.SearchColumns, field.column(name))
+			}
+		}
+		sort.Strings(f.SearchColumns)
+
+		if len(f.SearchColumns) == 0 {
+			return nil, errInvalidFilter("Search is not supported")
+		}
+	}
+
+	return f, nil
+}
+
+// column defaults to the param name
+func (f Field) column(name string) string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return name
+}
+
+func (f Field) parse(value string) (interface{}, error) {
+	switch f.Kind {
+	case Int:
+		return strconv.ParseInt(value, 10, 64)
+	case Bool:
+		return strconv.ParseBool(value)
+	case Time:
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, nil
+		}
+		return helper.ParseDate(value)
+	default:
+		return value, nil
+	}
+}
+
+func errInvalidFilter(message string) apperr.CustomErrorResponse {
+	return apperr.CustomError(message, entity.BAD_REQUEST_CODE, http.StatusBadRequest)
+}
@@ -0,0 +1,93 @@
+package filter_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+	"github.com/rahmatrdn/go-skeleton/internal/filter"
+	"github.com/stretchr/testify/suite"
+	gmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+var todoListFields = filter.Fields{
+	"user_id":     {Kind: filter.Int},
+	"done":        {Column: "is_done", Kind: filter.Bool},
+	"doing_at":    {Kind: filter.Time, Range: true},
+	"title":       {Searchable: true},
+	"description": {Searchable: true},
+}
+
+type FilterTestSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func TestFilter(t *testing.T) {
+	suite.Run(t, new(FilterTestSuite))
+}
+
+func (s *FilterTestSuite) SetupTest() {
+	conn, _, err := sqlmock.New()
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { conn.Close() })
+
+	dialector := gmysql.New(gmysql.Config{Conn: conn, SkipInitializeWithVersion: true})
+	s.db, err = gorm.Open(dialector, &gorm.Config{})
+	s.Require().NoError(err)
+}
+
+func (s *FilterTestSuite) sql(f *filter.Filter) string {
+	return s.db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("todo_lists").Scopes(f.Scope).Find(&[]map[string]interface{}{})
+	})
+}
+
+func (s *FilterTestSuite) TestAllowedFilter() {
+	f, err := filter.Parse(map[string]string{"done": "true"}, todoListFields)
+	s.Require().NoError(err)
+
+	s.Equal([]filter.Condition{{Column: "is_done", Op: filter.Eq, Value: true}}, f.Conditions)
+	s.Equal("SELECT * FROM `todo_lists` WHERE `is_done` = true", s.sql(f))
+}
+
+func (s *FilterTestSuite) TestDisallowedColumn() {
+	for _, query := range []map[string]string{
+		{"password": "secret"},
+		{"id = 1 OR 1": "1"},
+		{"user_id[gte]": "1"},
+		{"doing_at[between]": "2024-01-01"},
+		{"user_id": "1; DROP TABLE users"},
+	} {
+		_, err := filter.Parse(query, todoListFields)
+
+		s.Error(err, query)
+		s.Equal(http.StatusBadRequest, err.(apperr.CustomErrorResponse).HTTPCode, query)
+	}
+}
+
+func (s *FilterTestSuite) TestCombinedFilters() {
+	f, err := filter.Parse(map[string]string{
+		"user_id":       "3",
+		"doing_at[gte]": "2024-01-01",
+		"doing_at[lt]":  "2024-02-01T00:00:00Z",
+		"search":        "50%_off",
+		"page":          "2",
+	}, todoListFields, "page")
+	s.Require().NoError(err)
+
+	s.Equal(
+		"SELECT * FROM `todo_lists` WHERE `doing_at` >= '2024-01-01 00:00:00' AND `doing_at` < '2024-02-01 00:00:00' AND `user_id` = 3 "+
+			"AND (`description` LIKE '%50\\%\\_off%' OR `title` LIKE '%50\\%\\_off%')",
+		s.sql(f),
+	)
+	s.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), f.Conditions[0].Value)
+}
+
+func (s *FilterTestSuite) TestSearchWithoutSearchableFields() {
+	_, err := filter.Parse(map[string]string{"search": "milk"}, filter.Fields{"user_id": {Kind: filter.Int}})
+	s.Error(err)
+}
@@ -0,0 +1,57 @@
+package filter_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+	"github.com/rahmatrdn/go-skeleton/internal/filter"
+)
+
+// FuzzParse sends arbitrary query params to Parse. Whatever the input, it
+// fails with a 400 error or only lets the columns of todoListFields through,
+// the query never sees a param name. Run it with make fuzz.
+func FuzzParse(f *testing.F) {
+	f.Add("user_id", "12")
+	f.Add("done", "true")
+	f.Add("doing_at[gte]", "2024-01-01")
+	f.Add("doing_at[lt]", "2024-01-01T10:00:00Z")
+	f.Add("user_id[gt]", "1")
+	f.Add("title[like]", "milk")
+	f.Add("search", "milk")
+	f.Add("is_done", "1")
+	f.Add("[eq]", "x")
+	f.Add("doing_at[", "2024-01-01")
+	f.Add("user_id; DROP TABLE users", "1")
+
+	columns := map[string]bool{}
+	for name, field := range todoListFields {
+		if field.Column != "" {
+			name = field.Column
+		}
+		columns[name] = true
+	}
+
+	f.Fuzz(func(t *testing.T, param, value string) {
+		got, err := filter.Parse(map[string]string{param: value, "page": "1"}, todoListFields, "page")
+		if err != nil {
+			var appErr apperr.CustomErrorResponse
+			if !errors.As(err, &appErr) || appErr.HTTPCode != http.StatusBadRequest {
+				t.Errorf("Parse(%q=%q) error = %#v, want a 400 CustomErrorResponse", param, value, err)
+			}
+			return
+		}
+
+		for _, condition := range got.Conditions {
+			if !columns[condition.Column] {
+				t.Errorf("Parse(%q=%q) let the column %q through", param, value, condition.Column)
+			}
+		}
+		for _, column := range got.SearchColumns {
+			if !columns[column] {
+				t.Errorf("Parse(%q=%q) searches the column %q", param, value, column)
+			}
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package filter
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// likeEscaper makes the LIKE wildcards of a search term match literally, with
+// the backslash escape MySQL and PostgreSQL use by default
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Scope adds the filter to a GORM query, use it with db.Scopes(f.Scope). The
+// columns are quoted by GORM and the values are always bound parameters.
+func (f *Filter) Scope(db *gorm.DB) *gorm.DB {
+	for _, condition := range f.Conditions {
+		column := clause.Column{Name: condition.Column}
+
+		switch condition.Op {
+		case Ne:
+			db = db.Where(clause.Neq{Column: column, Value: condition.Value})
+		case Gt:
+			db = db.Where(clause.Gt{Column: column, Value: condition.Value})
+		case Gte:
+			db = db.Where(clause.Gte{Column: column, Value: condition.Value})
+		case Lt:
+			db = db.Where(clause.Lt{Column: column, Value: condition.Value})
+		case Lte:
+			db = db.Where(clause.Lte{Column: column, Value: condition.Value})
+		default:
+			db = db.Where(clause.Eq{Column: column, Value: condition.Value})
+		}
+	}
+
+	if f.Search != "" {
+		pattern := "%" + likeEscaper.Replace(f.Search) + "%"
+
+		matches := []clause.Expression{}
+		for _, column := range f.SearchColumns {
+			matches = append(matches, clause.Like{Column: clause.Column{Name: column}, Value: pattern})
+		}
+		db = db.Where(clause.Or(matches...))
+	}
+
+	return db
+}
+
+// Scope orders and limits a GORM query to the page, use it with
+// db.Scopes(f.Scope, page.Scope). It fetches Limit+1 rows, see Paginate.
+// Cursor pages continue after the After values:
+//
+//	WHERE (`created_at` < ? OR (`created_at` = ? AND `id` < ?))
+func (p *Page) Scope(db *gorm.DB) *gorm.DB {
+	for _, key := range p.Keys {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: key.Column}, Desc: p.Desc})
+	}
+	db = db.Limit(p.Limit + 1)
+
+	if p.Mode == OffsetPages {
+		return db.Offset(p.Offset())
+	}
+	if p.After == nil {
+		return db
+	}
+
+	after := []clause.Expression{}
+	for i, key := range p.Keys {
+		conditions := []clause.Expression{}
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, clause.Eq{Column: clause.Column{Name: p.Keys[j].Column}, Value: p.After[j]})
+		}
+		column := clause.Column{Name: key.Column}
+		if p.Desc {
+			conditions = append(conditions, clause.Lt{Column: column, Value: p.After[i]})
+		} else {
+			conditions = append(conditions, clause.Gt{Column: column, Value: p.After[i]})
+		}
+		after = append(after, clause.And(conditions...))
+	}
+	return db.Where(clause.Or(after...))
+}
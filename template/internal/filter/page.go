@@ -0,0 +1,206 @@
+package filter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// PageParam, LimitParam and CursorParam are the query params read by ParsePage
+const (
+	PageParam   = "page"
+	LimitParam  = "limit"
+	CursorParam = "cursor"
+)
+
+// PageParams are ignored by Parse, they are read by ParsePage:
+//
+//	f, err := filter.Parse(c.Queries(), fields, filter.PageParams...)
+var PageParams = []string{PageParam, LimitParam, CursorParam}
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+type PageMode int
+
+const (
+	// OffsetPages are numbered with ?page=2, each page skips the rows of the
+	// ones before it so they get slower the further they are
+	OffsetPages PageMode = iota
+	// CursorPages continue with ?cursor= after the last item of the previous
+	// page, every page is as fast as the first one and none skips or repeats
+	// an item when rows are inserted meanwhile
+	CursorPages
+)
+
+// SortKey is a column the pages are ordered by
+type SortKey struct {
+	Column string
+	Kind   Kind
+}
+
+// Paging configures the pagination of a list endpoint
+type Paging struct {
+	Mode PageMode
+	// Keys order the items. The last one has to be unique, usually the id, so
+	// items sharing the values of the others are never skipped or repeated.
+	Keys []SortKey
+	// Desc orders every key descending, e.g. the newest items first
+	Desc bool
+	// DefaultLimit is the page size without ?limit=, 20 when 0
+	DefaultLimit int
+	// MaxLimit is the largest ?limit= accepted, 100 when 0
+	MaxLimit int
+}
+
+// Page is the parsed page of a list request, turned into a query by
+// Page.Scope for GORM or Page.Bson and Page.FindOptions for MongoDB. The query
+// fetches one item more than Limit, Paginate trims it.
+type Page struct {
+	Paging
+	Limit int
+	// Number is the page of OffsetPages, from 1
+	Number int
+	// After holds the values of the Keys of the last item of the previous
+	// page for CursorPages, nil on the first page
+	After []interface{}
+}
+
+// PageInfo tells the client how to get the next page
+type PageInfo struct {
+	Limit      int    `json:"limit"`
+	Page       int    `json:"page,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// ParsePage reads ?page= and ?limit= of OffsetPages, or ?cursor= and ?limit=
+// of CursorPages:
+//
+//	page, err := filter.ParsePage(c.Queries(), filter.Paging{
+//		Mode: filter.CursorPages,
+//		Keys: []filter.SortKey{{Column: "created_at", Kind: filter.Time}, {Column: "id", Kind: filter.Int}},
+//		Desc: true,
+//	})
+func ParsePage(query map[string]string, paging Paging) (*Page, error) {
+	if paging.DefaultLimit == 0 {
+		paging.DefaultLimit = defaultPageLimit
+	}
+	if paging.MaxLimit == 0 {
+		paging.MaxLimit = maxPageLimit
+	}
+	page := &Page{Paging: paging, Limit: paging.DefaultLimit, Number: 1}
+
+	if value := query[LimitParam]; value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return nil, errInvalidFilter(fmt.Sprintf("Invalid value for %s: %s", LimitParam, value))
+		}
+		if limit > paging.MaxLimit {
+			return nil, errInvalidFilter(fmt.Sprintf("%s can't be more than %d", LimitParam, paging.MaxLimit))
+		}
+		page.Limit = limit
+	}
+
+	switch paging.Mode {
+	case CursorPages:
+		if query[PageParam] != "" {
+			return nil, errInvalidFilter(fmt.Sprintf("%s is not supported, use %s", PageParam, CursorParam))
+		}
+		if cursor := query[CursorParam]; cursor != "" {
+			after, err := paging.DecodeCursor(cursor)
+			if err != nil {
+				return nil, errInvalidFilter("Invalid cursor")
+			}
+			page.After = after
+		}
+	default:
+		if query[CursorParam] != "" {
+			return nil, errInvalidFilter(fmt.Sprintf("%s is not supported, use %s", CursorParam, PageParam))
+		}
+		if value := query[PageParam]; value != "" {
+			number, err := strconv.Atoi(value)
+			if err != nil || number < 1 {
+				return nil, errInvalidFilter(fmt.Sprintf("Invalid value for %s: %s", PageParam, value))
+			}
+			page.Number = number
+		}
+	}
+
+	return page, nil
+}
+
+// Offset is the number of items before the page of OffsetPages
+func (p *Page) Offset() int {
+	return (p.Number - 1) * p.Limit
+}
+
+// EncodeCursor returns the opaque cursor of the page after the item with the
+// values of the Keys
+func EncodeCursor(values ...interface{}) string {
+	keys := make([]string, len(values))
+	for i, value := range values {
+		switch value := value.(type) {
+		case time.Time:
+			keys[i] = value.Format(time.RFC3339Nano)
+		default:
+			keys[i] = fmt.Sprint(value)
+		}
+	}
+
+	encoded, _ := json.Marshal(keys)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// DecodeCursor returns the values of the Keys held by a cursor of
+// EncodeCursor, typed by the Kind of their key
+func (p Paging) DecodeCursor(cursor string) ([]interface{}, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	keys := []string{}
+	if err := json.Unmarshal(decoded, &keys); err != nil {
+		return nil, err
+	}
+	if len(keys) != len(p.Keys) {
+		return nil, fmt.Errorf("cursor has %d keys, expected %d", len(keys), len(p.Keys))
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, key := range p.Keys {
+		if values[i], err = (Field{Kind: key.Kind}).parse(keys[i]); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// Paginate trims items, fetched with the extra item of Page.Scope or
+// Page.FindOptions, to the page. key returns the values of the Keys of an
+// item, the next cursor is built from the last one; it may be nil for
+// OffsetPages.
+//
+//	todoLists, pageInfo := filter.Paginate(page, todoLists, func(t entity.TodoList) []interface{} {
+//		return []interface{}{t.CreatedAt, t.ID}
+//	})
+func Paginate[T any](p *Page, items []T, key func(T) []interface{}) ([]T, PageInfo) {
+	info := PageInfo{Limit: p.Limit}
+	if p.Mode == OffsetPages {
+		info.Page = p.Number
+	}
+	if len(items) <= p.Limit {
+		return items, info
+	}
+
+	items = items[:p.Limit]
+	info.HasMore = true
+	if p.Mode == CursorPages {
+		info.NextCursor = EncodeCursor(key(items[len(items)-1])...)
+	}
+	return items, info
+}
@@ -0,0 +1,178 @@
+package filter_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+	"github.com/rahmatrdn/go-skeleton/internal/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type todoList struct {
+	ID        int64
+	CreatedAt time.Time
+}
+
+func todoListKey(t todoList) []interface{} {
+	return []interface{}{t.CreatedAt, t.ID}
+}
+
+var newestTodoLists = filter.Paging{
+	Mode: filter.CursorPages,
+	Keys: []filter.SortKey{{Column: "created_at", Kind: filter.Time}, {Column: "id", Kind: filter.Int}},
+	Desc: true,
+}
+
+// todoListTable is ordered newest first, three todo lists share a created_at
+var todoListTable = []todoList{
+	{ID: 7, CreatedAt: time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+	{ID: 6, CreatedAt: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)},
+	{ID: 5, CreatedAt: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)},
+	{ID: 4, CreatedAt: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)},
+	{ID: 3, CreatedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+	{ID: 1, CreatedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+}
+
+// fetchTodoLists returns the rows of todoListTable the query of page.Scope
+// matches, newest first and limited to Limit+1
+func fetchTodoLists(table []todoList, page *filter.Page) []todoList {
+	rows := []todoList{}
+	for _, row := range table {
+		if page.After != nil {
+			after, id := page.After[0].(time.Time), page.After[1].(int64)
+			if !(row.CreatedAt.Before(after) || row.CreatedAt.Equal(after) && row.ID < id) {
+				continue
+			}
+		}
+		if len(rows) < page.Limit+1 {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func pageSQL(t *testing.T, page *filter.Page) string {
+	conn, _, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(gmysql.New(gmysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{})
+	require.NoError(t, err)
+	return db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("todo_lists").Scopes(page.Scope).Find(&[]map[string]interface{}{})
+	})
+}
+
+func TestCursorPagesIterateEveryItemOnce(t *testing.T) {
+	for _, table := range [][]todoList{todoListTable, todoListTable[:4]} {
+		seen := []int64{}
+		query := map[string]string{"limit": "2"}
+		pages := 0
+
+		for {
+			page, err := filter.ParsePage(query, newestTodoLists)
+			require.NoError(t, err)
+
+			items, info := filter.Paginate(page, fetchTodoLists(table, page), todoListKey)
+			for _, item := range items {
+				seen = append(seen, item.ID)
+			}
+			pages++
+
+			if info.NextCursor == "" {
+				assert.False(t, info.HasMore)
+				break
+			}
+			assert.True(t, info.HasMore)
+			query["cursor"] = info.NextCursor
+		}
+
+		want := []int64{}
+		for _, row := range table {
+			want = append(want, row.ID)
+		}
+		assert.Equal(t, want, seen)
+		// A last page as long as the limit doesn't leave an empty page behind
+		assert.Equal(t, (len(table)+1)/2, pages)
+	}
+}
+
+func TestCursorPageSQL(t *testing.T) {
+	page, err := filter.ParsePage(map[string]string{"limit": "2"}, newestTodoLists)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM `todo_lists` ORDER BY `created_at` DESC,`id` DESC LIMIT 3", pageSQL(t, page))
+
+	cursor := filter.EncodeCursor(todoListKey(todoListTable[2])...)
+	page, err = filter.ParsePage(map[string]string{"limit": "2", "cursor": cursor}, newestTodoLists)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{todoListTable[2].CreatedAt, int64(5)}, page.After)
+	assert.Equal(t, "SELECT * FROM `todo_lists` WHERE (`created_at` < '2024-03-02 00:00:00' OR (`created_at` = '2024-03-02 00:00:00' AND `id` < 5)) ORDER BY `created_at` DESC,`id` DESC LIMIT 3", pageSQL(t, page))
+}
+
+func TestOffsetPages(t *testing.T) {
+	paging := filter.Paging{Keys: []filter.SortKey{{Column: "id", Kind: filter.Int}}}
+
+	page, err := filter.ParsePage(map[string]string{"page": "3", "limit": "2"}, paging)
+	require.NoError(t, err)
+	assert.Equal(t, 4, page.Offset())
+	assert.Equal(t, "SELECT * FROM `todo_lists` ORDER BY `id` LIMIT 3 OFFSET 4", pageSQL(t, page))
+
+	items, info := filter.Paginate(page, todoListTable[4:], nil)
+	assert.Len(t, items, 2)
+	assert.Equal(t, filter.PageInfo{Limit: 2, Page: 3}, info)
+
+	items, info = filter.Paginate(page, todoListTable[1:4], nil)
+	assert.Len(t, items, 2)
+	assert.Equal(t, filter.PageInfo{Limit: 2, Page: 3, HasMore: true}, info)
+}
+
+func TestParsePageDefaults(t *testing.T) {
+	page, err := filter.ParsePage(map[string]string{}, filter.Paging{})
+	require.NoError(t, err)
+	assert.Equal(t, 20, page.Limit)
+	assert.Equal(t, 1, page.Number)
+	assert.Zero(t, page.Offset())
+	assert.Nil(t, page.After)
+}
+
+func TestParsePageInvalid(t *testing.T) {
+	offset := filter.Paging{Keys: []filter.SortKey{{Column: "id", Kind: filter.Int}}}
+
+	for _, tc := range []struct {
+		query   map[string]string
+		paging  filter.Paging
+		message string
+	}{
+		{map[string]string{"limit": "0"}, offset, "Invalid value for limit: 0"},
+		{map[string]string{"limit": "101"}, offset, "limit can't be more than 100"},
+		{map[string]string{"page": "-1"}, offset, "Invalid value for page: -1"},
+		{map[string]string{"cursor": "abc"}, offset, "cursor is not supported, use page"},
+		{map[string]string{"page": "2"}, newestTodoLists, "page is not supported, use cursor"},
+		{map[string]string{"cursor": "not a cursor"}, newestTodoLists, "Invalid cursor"},
+		// A cursor of another endpoint, with different keys
+		{map[string]string{"cursor": filter.EncodeCursor(int64(5))}, newestTodoLists, "Invalid cursor"},
+		{map[string]string{"cursor": filter.EncodeCursor("yesterday", int64(5))}, newestTodoLists, "Invalid cursor"},
+	} {
+		_, err := filter.ParsePage(tc.query, tc.paging)
+
+		var appErr apperr.CustomErrorResponse
+		require.ErrorAs(t, err, &appErr, tc.message)
+		assert.Equal(t, http.StatusBadRequest, appErr.HTTPCode)
+		assert.Equal(t, tc.message, appErr.Message)
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 3, 2, 10, 30, 15, 123456789, time.UTC)
+	paging := filter.Paging{Keys: []filter.SortKey{{Column: "created_at", Kind: filter.Time}, {Column: "title", Kind: filter.String}, {Column: "id", Kind: filter.Int}}}
+
+	values, err := paging.DecodeCursor(filter.EncodeCursor(createdAt, "milk, eggs", int64(42)))
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{createdAt, "milk, eggs", int64(42)}, values)
+}
@@ -0,0 +1,31 @@
+package graphql
+
+import (
+	"context"
+
+	todo_list_usecase "github.com/rahmatrdn/go-skeleton/internal/usecase/todo_list"
+)
+
+// Resolver is the root resolver, it holds the usecases the resolvers delegate to
+// like the REST handlers do.
+type Resolver struct {
+	todoListUsecase todo_list_usecase.ICrudTodoListUsecase
+}
+
+func NewResolver(todoListUsecase todo_list_usecase.ICrudTodoListUsecase) *Resolver {
+	return &Resolver{todoListUsecase}
+}
+
+type userIDKey struct{}
+
+// WithUserID returns ctx carrying the authenticated user ID, the GraphQL handler
+// sets it from the auth middleware
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext returns the user ID set with WithUserID, 0 without one
+func UserIDFromContext(ctx context.Context) int64 {
+	userID, _ := ctx.Value(userIDKey{}).(int64)
+	return userID
+}
@@ -0,0 +1,42 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"context"
+
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+	"github.com/rahmatrdn/go-skeleton/internal/usecase/todo_list/entity"
+)
+
+// CreateTodoList is the resolver for the createTodoList field.
+func (r *mutationResolver) CreateTodoList(ctx context.Context, input entity.TodoListReq) (*entity.TodoListResponse, error) {
+	userID := UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, apperr.ErrInvalidToken()
+	}
+	input.SetUserID(userID)
+
+	return r.todoListUsecase.Create(ctx, input)
+}
+
+// TodoLists is the resolver for the todoLists field.
+func (r *queryResolver) TodoLists(ctx context.Context) ([]*entity.TodoListResponse, error) {
+	userID := UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, apperr.ErrInvalidToken()
+	}
+
+	return r.todoListUsecase.GetByUserID(ctx, userID)
+}
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type mutationResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
@@ -0,0 +1,112 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Check reports why a dependency isn't ready, nil when it is
+type Check func(ctx context.Context) error
+
+// Run runs every check with timeout to answer, returning the error of each
+// check by name, nil for the passing ones
+func Run(ctx context.Context, checks map[string]Check, timeout time.Duration) map[string]error {
+	results := map[string]error{}
+	for name, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		results[name] = check(checkCtx)
+		cancel()
+	}
+
+	return results
+}
+
+// Result always reports err, for a dependency checked once while it was set up
+func Result(err error) Check {
+	return func(context.Context) error {
+		return err
+	}
+}
+
+// Ping checks the database answers
+func Ping(db *gorm.DB) Check {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// Migrations checks the version golang-migrate applied to db is the latest one
+// of migrations. A schema behind or ahead of the binary, or a dirty migration,
+// makes the service not ready.
+func Migrations(db *gorm.DB, migrations fs.FS) Check {
+	return func(ctx context.Context) error {
+		latest, err := LatestMigration(migrations)
+		if err != nil {
+			return err
+		}
+
+		applied, dirty, err := AppliedMigration(ctx, db)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("migration %d is dirty", applied)
+		}
+		if applied != latest {
+			return fmt.Errorf("migration %d applied, %d is the latest", applied, latest)
+		}
+
+		return nil
+	}
+}
+
+// LatestMigration returns the highest version among the up migrations of
+// migrations, named like 000001_create_table_users.up.sql
+func LatestMigration(migrations fs.FS) (uint64, error) {
+	files, err := fs.Glob(migrations, "*.up.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint64
+	for _, file := range files {
+		prefix, _, _ := strings.Cut(file, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("migration %s has no version: %w", file, err)
+		}
+		if version > latest {
+			latest = version
+		}
+	}
+
+	return latest, nil
+}
+
+// AppliedMigration returns the version golang-migrate recorded in
+// schema_migrations, 0 when no migration ran yet
+func AppliedMigration(ctx context.Context, db *gorm.DB) (version uint64, dirty bool, err error) {
+	var rows []struct {
+		Version uint64
+		Dirty   bool
+	}
+	if err := db.WithContext(ctx).Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&rows).Error; err != nil {
+		return 0, false, err
+	}
+	if len(rows) == 0 {
+		return 0, false, nil
+	}
+
+	return rows[0].Version, rows[0].Dirty, nil
+}
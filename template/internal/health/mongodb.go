@@ -0,0 +1,14 @@
+package health
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Mongo checks the MongoDB server of db answers
+func Mongo(db *mongo.Database) Check {
+	return func(ctx context.Context) error {
+		return db.Client().Ping(ctx, nil)
+	}
+}
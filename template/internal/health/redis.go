@@ -0,0 +1,14 @@
+package health
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis checks the Redis server answers
+func Redis(client *redis.Client) Check {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}
+}
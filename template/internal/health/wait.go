@@ -0,0 +1,78 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxWaitInterval caps the backoff of WaitFor
+const maxWaitInterval = 10 * time.Second
+
+// WaitFor runs check until it passes, at most attempts times. The wait between
+// attempts starts at interval and doubles up to maxWaitInterval, giving a
+// database container that is still starting the time it needs.
+func WaitFor(ctx context.Context, check Check, attempts int, interval time.Duration) error {
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = check(ctx); err == nil {
+			return nil
+		}
+		if attempt == attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, maxWaitInterval)
+	}
+
+	return fmt.Errorf("not ready after %d attempts: %w", attempts, err)
+}
+
+// startupInterval is the first wait of WaitReady, doubled after each attempt
+const startupInterval = 500 * time.Millisecond
+
+// WaitReady runs check until it passes or ctx is done, logging each failed
+// attempt. Compose's depends_on only waits for a container to start, not for
+// the service in it to answer, so the API retries its dependencies on startup
+// instead of crashing.
+func WaitReady(ctx context.Context, logger *zap.Logger, name string, check Check) error {
+	attempt := 0
+	var lastErr error
+	logged := func(ctx context.Context) error {
+		attempt++
+		if lastErr = check(ctx); lastErr != nil {
+			logger.Warn("Dependency not ready, retrying", zap.String("dependency", name), zap.Int("attempt", attempt), zap.Error(lastErr))
+		}
+		return lastErr
+	}
+
+	if err := WaitFor(ctx, logged, math.MaxInt, startupInterval); err != nil {
+		if lastErr == nil {
+			lastErr = err
+		}
+		return fmt.Errorf("%s not ready after %d attempts: %w", name, attempt, lastErr)
+	}
+	logger.Info("Dependency ready", zap.String("dependency", name), zap.Int("attempts", attempt))
+
+	return nil
+}
+
+// Open calls open until it returns the connection, with the retries of
+// WaitReady, for the clients that fail to open while their server is down
+func Open[T any](ctx context.Context, logger *zap.Logger, name string, open func(ctx context.Context) (T, error)) (T, error) {
+	var conn T
+	err := WaitReady(ctx, logger, name, func(ctx context.Context) (err error) {
+		conn, err = open(ctx)
+		return err
+	})
+
+	return conn, err
+}
@@ -0,0 +1,101 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/internal/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var errNotReady = errors.New("connection refused")
+
+// flakyCheck fails until it has been called readyAfter times
+func flakyCheck(readyAfter int, calls *int) health.Check {
+	return func(context.Context) error {
+		*calls++
+		if *calls < readyAfter {
+			return errNotReady
+		}
+		return nil
+	}
+}
+
+func TestWaitFor(t *testing.T) {
+	t.Run("ready after a few tries", func(t *testing.T) {
+		calls := 0
+		err := health.WaitFor(context.Background(), flakyCheck(4, &calls), 5, time.Millisecond)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, calls)
+	})
+
+	t.Run("gives up after the attempts", func(t *testing.T) {
+		calls := 0
+		err := health.WaitFor(context.Background(), flakyCheck(10, &calls), 3, time.Millisecond)
+
+		assert.ErrorIs(t, err, errNotReady)
+		assert.ErrorContains(t, err, "not ready after 3 attempts")
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		calls := 0
+		err := health.WaitFor(ctx, flakyCheck(10, &calls), 5, time.Hour)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestWaitReady(t *testing.T) {
+	t.Run("logs each attempt until ready", func(t *testing.T) {
+		core, logs := observer.New(zap.InfoLevel)
+
+		calls := 0
+		err := health.WaitReady(context.Background(), zap.New(core), "mysql", flakyCheck(3, &calls))
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+
+		retries := logs.FilterMessage("Dependency not ready, retrying").All()
+		require.Len(t, retries, 2)
+		assert.Equal(t, "mysql", retries[0].ContextMap()["dependency"])
+		assert.Equal(t, int64(2), retries[1].ContextMap()["attempt"])
+		assert.Equal(t, 1, logs.FilterMessage("Dependency ready").Len())
+	})
+
+	t.Run("gives up when the wait times out", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		calls := 0
+		err := health.WaitReady(ctx, zap.NewNop(), "redis", flakyCheck(1000, &calls))
+
+		assert.ErrorIs(t, err, errNotReady)
+		assert.ErrorContains(t, err, "redis not ready after 1 attempts")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestOpen(t *testing.T) {
+	calls := 0
+	conn, err := health.Open(context.Background(), zap.NewNop(), "rabbitmq", func(ctx context.Context) (string, error) {
+		if err := flakyCheck(2, &calls)(ctx); err != nil {
+			return "", err
+		}
+		return "connected", nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "connected", conn)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,124 @@
+package helper
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultTimezone is used when APP_TIMEZONE isn't set
+const DefaultTimezone = "Asia/Jakarta"
+
+var (
+	clock        = time.Now
+	locationOnce sync.Once
+	location     *time.Location
+)
+
+// Now returns the current time in APP_TIMEZONE, use it instead of time.Now so
+// timestamps share the zone and tests can fix the time with SetClock
+func Now() time.Time {
+	return clock().In(Location())
+}
+
+// FormatTime formats t with layout in APP_TIMEZONE
+func FormatTime(t time.Time, layout string) string {
+	return t.In(Location()).Format(layout)
+}
+
+// Location returns the APP_TIMEZONE location, read on first use. An unknown
+// timezone falls back to UTC.
+func Location() *time.Location {
+	locationOnce.Do(func() {
+		name := os.Getenv("APP_TIMEZONE")
+		if name == "" {
+			name = DefaultTimezone
+		}
+
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			fmt.Println(fmt.Sprintf("INVALID APP_TIMEZONE %q, USING UTC: %s", name, err.Error()))
+			loc = time.UTC
+		}
+		location = loc
+	})
+
+	return location
+}
+
+// SetTimezone replaces the APP_TIMEZONE location, for tests and programs
+// setting the zone themselves
+func SetTimezone(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return err
+	}
+
+	locationOnce.Do(func() {})
+	location = loc
+
+	return nil
+}
+
+// SetClock makes Now read the time from now, the returned function restores
+// the previous clock. Tests use it to get deterministic timestamps.
+func SetClock(now func() time.Time) (restore func()) {
+	previous := clock
+	clock = now
+
+	return func() { clock = previous }
+}
+
+// Clock tells the time. Components take one instead of calling time.Now, so
+// tests can control the time with a FakeClock.
+type Clock interface {
+	Now() time.Time
+}
+
+// RealClock is the Clock of helper.Now
+type RealClock struct{}
+
+func (RealClock) Now() time.Time {
+	return Now()
+}
+
+// FakeClock is a Clock standing still until it's Set or Advanced
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+}
+
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// OrRealClock returns clock, or the RealClock when it's nil
+func OrRealClock(clock Clock) Clock {
+	if clock == nil {
+		return RealClock{}
+	}
+
+	return clock
+}
@@ -0,0 +1,57 @@
+package helper_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/config"
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNowUsesClockAndTimezone(t *testing.T) {
+	require.NoError(t, helper.SetTimezone("Asia/Jakarta"))
+	restore := helper.SetClock(func() time.Time {
+		return time.Date(2025, 7, 1, 17, 30, 0, 0, time.UTC)
+	})
+	defer restore()
+
+	now := helper.Now()
+	assert.Equal(t, "Asia/Jakarta", now.Location().String())
+	assert.Equal(t, "2025-07-02 00:30:00", helper.FormatTime(now, "2006-01-02 15:04:05"))
+	assert.Equal(t, "2025-07-02", helper.DateNowJakarta())
+	assert.Equal(t, "2025-07-01T17:30:00", helper.NowStrUTC())
+}
+
+func TestProductionLogFileIsDatedWithNow(t *testing.T) {
+	require.NoError(t, helper.SetTimezone("Asia/Jakarta"))
+	restore := helper.SetClock(func() time.Time {
+		return time.Date(2025, 7, 31, 17, 30, 0, 0, time.UTC)
+	})
+	defer restore()
+	t.Chdir(t.TempDir())
+
+	logger, err := config.NewProductionLogger()
+	require.NoError(t, err)
+	logger.Info("dated")
+	require.NoError(t, logger.Sync())
+
+	assert.FileExists(t, filepath.Join("storage/log", "2025/08", "2025-08-01.log"), "the date is the one of APP_TIMEZONE")
+}
+
+func TestFormatTimeInConfiguredTimezone(t *testing.T) {
+	require.NoError(t, helper.SetTimezone("Europe/London"))
+	defer helper.SetTimezone(helper.DefaultTimezone)
+
+	winter := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	summer := time.Date(2025, 7, 15, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "2025-01-15 12:00:00", helper.FormatTime(winter, "2006-01-02 15:04:05"))
+	assert.Equal(t, "2025-07-15 13:00:00", helper.ConvertToJakartaTime(summer))
+}
+
+func TestSetTimezoneRejectsUnknownZone(t *testing.T) {
+	assert.Error(t, helper.SetTimezone("Mars/Olympus_Mons"))
+}
@@ -0,0 +1,186 @@
+// Package convert turns loosely typed values, like decoded JSON or queue
+// payload fields, into Go types. Every To* function returns an error for
+// values it can't convert, its To*Or sibling returns a default instead.
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedType is returned for values of a type that can't be converted
+var ErrUnsupportedType = errors.New("unsupported type")
+
+// ToInt64 converts integers, whole floats, json.Number and numeric strings.
+// Out of range values return an error wrapping strconv.ErrRange.
+func ToInt64(v interface{}) (int64, error) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint:
+		return uintToInt64(uint64(v))
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return uintToInt64(v)
+	case float32:
+		return floatToInt64(float64(v))
+	case float64:
+		return floatToInt64(v)
+	case json.Number:
+		return parseInt(string(v))
+	case string:
+		return parseInt(v)
+	default:
+		return 0, unsupported(v, "int64")
+	}
+}
+
+// ToInt is ToInt64 for values in the range of int
+func ToInt(v interface{}) (int, error) {
+	n, err := ToInt64(v)
+	if err != nil {
+		return 0, err
+	}
+	if n < math.MinInt || n > math.MaxInt {
+		return 0, rangeError("ToInt", v)
+	}
+	return int(n), nil
+}
+
+// ToFloat converts numbers, json.Number and numeric strings
+func ToFloat(v interface{}) (float64, error) {
+	switch v := v.(type) {
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	}
+
+	n, err := ToInt64(v)
+	if err != nil {
+		return 0, err
+	}
+	return float64(n), nil
+}
+
+// ToBool converts booleans and the strings accepted by strconv.ParseBool
+func ToBool(v interface{}) (bool, error) {
+	switch v := v.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(strings.TrimSpace(v))
+	default:
+		return false, unsupported(v, "bool")
+	}
+}
+
+// ToTime converts time.Time, RFC 3339 timestamps and 2006-01-02 dates
+func ToTime(v interface{}) (time.Time, error) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		v = strings.TrimSpace(v)
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, nil
+		}
+		return time.Parse("2006-01-02", v)
+	default:
+		return time.Time{}, unsupported(v, "time")
+	}
+}
+
+func ToIntOr(v interface{}, fallback int) int {
+	if n, err := ToInt(v); err == nil {
+		return n
+	}
+	return fallback
+}
+
+func ToInt64Or(v interface{}, fallback int64) int64 {
+	if n, err := ToInt64(v); err == nil {
+		return n
+	}
+	return fallback
+}
+
+func ToFloatOr(v interface{}, fallback float64) float64 {
+	if f, err := ToFloat(v); err == nil {
+		return f
+	}
+	return fallback
+}
+
+func ToBoolOr(v interface{}, fallback bool) bool {
+	if b, err := ToBool(v); err == nil {
+		return b
+	}
+	return fallback
+}
+
+func ToTimeOr(v interface{}, fallback time.Time) time.Time {
+	if t, err := ToTime(v); err == nil {
+		return t
+	}
+	return fallback
+}
+
+// parseInt returns 0 instead of the clamped value strconv.ParseInt returns
+// with a range error
+func parseInt(s string) (int64, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func uintToInt64(v uint64) (int64, error) {
+	if v > math.MaxInt64 {
+		return 0, rangeError("ToInt64", v)
+	}
+	return int64(v), nil
+}
+
+// floatToInt64 rejects fractions instead of truncating them
+func floatToInt64(v float64) (int64, error) {
+	if v != math.Trunc(v) {
+		return 0, &strconv.NumError{Func: "ToInt64", Num: fmt.Sprint(v), Err: strconv.ErrSyntax}
+	}
+	// float64(math.MaxInt64) rounds up to 2^63, which is already out of range
+	if v < math.MinInt64 || v >= math.MaxInt64 {
+		return 0, rangeError("ToInt64", v)
+	}
+	return int64(v), nil
+}
+
+func rangeError(funcName string, v interface{}) error {
+	return &strconv.NumError{Func: funcName, Num: fmt.Sprint(v), Err: strconv.ErrRange}
+}
+
+func unsupported(v interface{}, to string) error {
+	return fmt.Errorf("convert %T to %s: %w", v, to, ErrUnsupportedType)
+}
@@ -0,0 +1,156 @@
+package convert_test
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/internal/helper/convert"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToInt64(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+		want  int64
+		err   error
+	}{
+		{"int", 42, 42, nil},
+		{"int32", int32(-7), -7, nil},
+		{"uint64", uint64(9), 9, nil},
+		{"whole float", 120.0, 120, nil},
+		{"json number", json.Number("1500"), 1500, nil},
+		{"string", "250", 250, nil},
+		{"padded string", " 250 ", 250, nil},
+		{"negative string", "-3", -3, nil},
+		{"empty string", "", 0, strconv.ErrSyntax},
+		{"not a number", "slow", 0, strconv.ErrSyntax},
+		{"decimal string", "1.5", 0, strconv.ErrSyntax},
+		{"fraction", 1.5, 0, strconv.ErrSyntax},
+		{"NaN", math.NaN(), 0, strconv.ErrSyntax},
+		{"string overflow", "9223372036854775808", 0, strconv.ErrRange},
+		{"uint overflow", uint64(math.MaxUint64), 0, strconv.ErrRange},
+		{"float overflow", 1e19, 0, strconv.ErrRange},
+		{"infinity", math.Inf(-1), 0, strconv.ErrRange},
+		{"nil", nil, 0, convert.ErrUnsupportedType},
+		{"bool", true, 0, convert.ErrUnsupportedType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convert.ToInt64(tt.input)
+			assert.ErrorIs(t, err, tt.err)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestToIntOr(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+		want  int
+	}{
+		{"valid", "12", 12},
+		{"empty string", "", -1},
+		{"not a number", "12ms", -1},
+		{"overflow", "99999999999999999999", -1},
+		{"nil", nil, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, convert.ToIntOr(tt.input, -1))
+		})
+	}
+}
+
+func TestToFloat(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+		want  float64
+		fails bool
+	}{
+		{"float", 1.25, 1.25, false},
+		{"int", 3, 3, false},
+		{"json number", json.Number("0.5"), 0.5, false},
+		{"string", "2.75", 2.75, false},
+		{"empty string", "", 0, true},
+		{"not a number", "fast", 0, true},
+		{"overflow", "1e400", 0, true},
+		{"bool", false, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convert.ToFloat(tt.input)
+			assert.Equal(t, tt.fails, err != nil)
+			if !tt.fails {
+				assert.Equal(t, tt.want, got)
+			}
+			assert.Equal(t, tt.want, convert.ToFloatOr(tt.input, 0))
+		})
+	}
+}
+
+func TestToBool(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+		want  bool
+		fails bool
+	}{
+		{"bool", true, true, false},
+		{"string", "false", false, false},
+		{"digit", "1", true, false},
+		{"empty string", "", false, true},
+		{"yes", "yes", false, true},
+		{"int", 1, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convert.ToBool(tt.input)
+			assert.Equal(t, tt.fails, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.True(t, convert.ToBoolOr("yes", true))
+}
+
+func TestToTime(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	timestamp := time.Date(2024, 3, 1, 8, 30, 0, 0, time.FixedZone("", 7*60*60))
+
+	tests := []struct {
+		name  string
+		input interface{}
+		want  time.Time
+		fails bool
+	}{
+		{"time", date, date, false},
+		{"date", "2024-03-01", date, false},
+		{"RFC 3339", "2024-03-01T08:30:00+07:00", timestamp, false},
+		{"empty string", "", time.Time{}, true},
+		{"invalid date", "2024-13-01", time.Time{}, true},
+		{"unix seconds", int64(1709251200), time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convert.ToTime(tt.input)
+			assert.Equal(t, tt.fails, err != nil)
+			assert.True(t, tt.want.Equal(got))
+		})
+	}
+
+	assert.Equal(t, date, convert.ToTimeOr("someday", date))
+}
@@ -0,0 +1,63 @@
+package convert_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/internal/helper/convert"
+)
+
+// The Fuzz functions run their seeds with go test and generate inputs with
+// make fuzz. Failing inputs are saved to testdata/fuzz, commit them so they
+// keep running as regular tests once fixed.
+
+func FuzzToInt64(f *testing.F) {
+	for _, seed := range []string{"0", "250", " -3 ", "+7", "1.5", "1e3", "0x1F", "", "9223372036854775807", "9223372036854775808", "-9223372036854775809"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		n, err := convert.ToInt64(s)
+		if err != nil {
+			if n != 0 {
+				t.Errorf("ToInt64(%q) = %d with error %v, want 0", s, n, err)
+			}
+			return
+		}
+
+		// A converted string is the decimal form of the number, padding and sign aside
+		back, err := convert.ToInt64(strconv.FormatInt(n, 10))
+		if err != nil || back != n {
+			t.Errorf("ToInt64(%q) = %d doesn't round trip: %d, %v", s, n, back, err)
+		}
+		if f, err := convert.ToFloat(s); err != nil || f != float64(n) {
+			t.Errorf("ToInt64(%q) = %d but ToFloat = %v, %v", s, n, f, err)
+		}
+	})
+}
+
+func FuzzToTime(f *testing.F) {
+	for _, seed := range []string{"2024-01-31", "2024-02-30", "2024-01-31T10:00:00Z", "2024-01-31T10:00:00+07:00", " 2024-01-31 ", "31/01/2024", ""} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		got, err := convert.ToTime(s)
+		if err != nil {
+			if !got.IsZero() {
+				t.Errorf("ToTime(%q) = %v with error %v, want the zero time", s, got, err)
+			}
+			return
+		}
+
+		// Whatever was accepted is a valid RFC 3339 timestamp once formatted
+		if again, err := time.Parse(time.RFC3339Nano, got.Format(time.RFC3339Nano)); err != nil || !again.Equal(got) {
+			t.Errorf("ToTime(%q) = %v doesn't round trip: %v, %v", s, got, again, err)
+		}
+		if strings.TrimSpace(s) == "" {
+			t.Errorf("ToTime(%q) accepted an empty string", s)
+		}
+	})
+}
@@ -4,31 +4,26 @@ import (
 	"time"
 )
 
-func DateNowJakarta() string {
-	loc, _ := time.LoadLocation("Asia/Jakarta")
+// The Jakarta helpers use APP_TIMEZONE, their names predate the setting and
+// its Asia/Jakarta default
 
-	return time.Now().In(loc).Format("2006-01-02")
+func DateNowJakarta() string {
+	return Now().Format("2006-01-02")
 }
 func DatetimeNowJakartaString() string {
-	loc, _ := time.LoadLocation("Asia/Jakarta")
-
-	return time.Now().In(loc).Format("2006-01-02 15:04:05")
+	return Now().Format("2006-01-02 15:04:05")
 }
 
 func AddMinutes(m int) string {
-	loc, _ := time.LoadLocation("Asia/Jakarta")
-	return time.Now().In(loc).Add(time.Minute * time.Duration(m)).Format("2006-01-02 15:04:05")
+	return Now().Add(time.Minute * time.Duration(m)).Format("2006-01-02 15:04:05")
 }
 
 func DateFilename() string {
-	loc, _ := time.LoadLocation("Asia/Jakarta")
-	return time.Now().In(loc).Format("20060102150405")
+	return Now().Format("20060102150405")
 }
 
 func DatetimeNowJakarta() time.Time {
-	jakartaLocation, _ := time.LoadLocation("Asia/Jakarta")
-
-	return time.Now().In(jakartaLocation)
+	return Now()
 }
 
 func ParseDate(dateStr string) (time.Time, error) {
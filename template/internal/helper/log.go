@@ -10,6 +10,10 @@ import (
 	"go.uber.org/zap"
 )
 
+func init() {
+	config.LogClock = Now
+}
+
 func WriteLogToFile(data string, channel string) error {
 	dir := filepath.Dir(channel)
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
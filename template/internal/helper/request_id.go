@@ -0,0 +1,26 @@
+package helper
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID returns ctx carrying the ID of the HTTP request it serves
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the ID of the HTTP request ctx serves, empty outside of a
+// request. Handlers passing c.Context() don't need WithRequestID, the fasthttp
+// context holds the ID stored by the requestid middleware.
+func RequestID(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+
+	id, _ := ctx.Value(requestid.ConfigDefault.ContextKey).(string)
+	return id
+}
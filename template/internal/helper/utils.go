@@ -128,9 +128,7 @@ func StructToMap(m any, nonZeroVal bool) map[string]any {
 }
 
 func NowStrUTC() string {
-	return fmt.Sprintf("%d-%02d-%02dT%02d:%02d:%02d",
-		time.Now().UTC().Year(), time.Now().UTC().Month(), time.Now().UTC().Day(),
-		time.Now().UTC().Hour(), time.Now().UTC().Minute(), time.Now().UTC().Second())
+	return Now().UTC().Format("2006-01-02T15:04:05")
 }
 
 func InArray(val interface{}, array interface{}) (found bool) {
@@ -200,14 +198,14 @@ func VerifyBcryptHash(plaintext, hash string) bool {
 	return err == nil
 }
 
+// ConvertToJakartaTime formats t in APP_TIMEZONE, see DateNowJakarta
 func ConvertToJakartaTime(t time.Time) string {
-	loc, _ := time.LoadLocation("Asia/Jakarta")
-	return t.In(loc).Format("2006-01-02 15:04:05")
+	return FormatTime(t, "2006-01-02 15:04:05")
 }
 
+// ConvertToJakartaDate formats the date of t in APP_TIMEZONE
 func ConvertToJakartaDate(t time.Time) string {
-	loc, _ := time.LoadLocation("Asia/Jakarta")
-	return t.In(loc).Format("2006-01-02")
+	return FormatTime(t, "2006-01-02")
 }
 
 func GetAppEnv() string {
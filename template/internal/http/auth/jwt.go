@@ -9,6 +9,7 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/rahmatrdn/go-skeleton/config"
 	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
 	mentity "github.com/rahmatrdn/go-skeleton/internal/repository/mysql/entity"
 )
 
@@ -17,10 +18,13 @@ const (
 	publicKeyPath  = "public_key.pem"
 )
 
-type JWT struct{}
+type JWT struct {
+	// Clock sets when the generated tokens expire, the real clock by default
+	Clock helper.Clock
+}
 
 func NewJWTAuth() *JWT {
-	return &JWT{}
+	return &JWT{Clock: helper.RealClock{}}
 }
 
 type JWTAuth interface {
@@ -42,7 +46,7 @@ func (j *JWT) GenerateToken(user *mentity.User) (string, error) {
 
 	claims := &entity.Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(cfg.JwtExpireDaysCount) * 24 * time.Hour)),
+			ExpiresAt: expiresAt(j.Clock.Now(), cfg.JwtExpireDaysCount),
 		},
 		Email:      user.Email,
 		UserID:     user.ID,
@@ -58,6 +62,11 @@ func (j *JWT) GenerateToken(user *mentity.User) (string, error) {
 	return signedToken, nil
 }
 
+// expiresAt is when a token issued at now expires, after JWT_EXPIRE_DAYS_COUNT days
+func expiresAt(now time.Time, days int) *jwt.NumericDate {
+	return jwt.NewNumericDate(now.Add(time.Duration(days) * 24 * time.Hour))
+}
+
 func VerifyToken(c *fiber.Ctx) error {
 	authHeader := c.Get("Authorization")
 	if authHeader == "" {
@@ -119,7 +128,7 @@ func RefreshToken(c *fiber.Ctx) (string, error) {
 	}
 
 	// Update expiry
-	claims.RegisteredClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Duration(cfg.JwtExpireDaysCount) * 24 * time.Hour))
+	claims.RegisteredClaims.ExpiresAt = expiresAt(helper.Now(), cfg.JwtExpireDaysCount)
 
 	privateKeyBytes, err := os.ReadFile(privateKeyPath)
 	if err != nil {
@@ -103,6 +103,16 @@ func (w *AuthHandler) CheckToken(c *fiber.Ctx) error {
 	return w.presenter.BuildSuccess(c, "Token is valid!", "Success", http.StatusOK)
 }
 
+// Refresh Token
+// @Summary			Refresh Token
+// @Description		Issue a new token for a valid one, with a new expiry
+// @Tags			Auth
+// @Produce			json
+// @Security 		Bearer
+// @Success			200 {object} entity.GeneralResponse{data=string} "Success"
+// @Failure			401 {object} entity.CustomErrorResponse "Invalid Access Token"
+// @Failure			500 {object} entity.CustomErrorResponse "Internal server Error"
+// @Router			/api/v1/auth/refresh-token [get]
 func (w *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 	newToken, err := auth.RefreshToken(c)
 	if err != nil {
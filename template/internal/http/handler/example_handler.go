@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"go.uber.org/zap"
+
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+type ExampleHandler struct {
+	zapLogger *zap.Logger
+}
+
+func NewExampleHandler(zapLogger *zap.Logger) *ExampleHandler {
+	return &ExampleHandler{zapLogger}
+}
+
+func (w *ExampleHandler) Register(app fiber.Router) {
+	app.Get("/examples/ping", w.Ping)
+}
+
+// @Summary         Ping
+// @Description     Reply with pong and log the request
+// @Tags            Example
+// @Produce         json
+// @Success			200 {object} entity.GeneralResponse "Success"
+// @Router			/api/v1/examples/ping [get]
+func (w *ExampleHandler) Ping(c *fiber.Ctx) error {
+	w.zapLogger.Info("ping received",
+		zap.String("ip", c.IP()),
+		zap.String("user_agent", c.Get(fiber.HeaderUserAgent)),
+	)
+
+	return c.Status(http.StatusOK).JSON(entity.GeneralResponse{
+		Code:    http.StatusOK,
+		Message: "pong",
+	})
+}
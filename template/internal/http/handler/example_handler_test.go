@@ -0,0 +1,46 @@
+package handler_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rahmatrdn/go-skeleton/internal/http/handler"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type ExampleHandlerTestSuite struct {
+	suite.Suite
+	logs    *observer.ObservedLogs
+	handler *handler.ExampleHandler
+}
+
+func (s *ExampleHandlerTestSuite) SetupTest() {
+	core, logs := observer.New(zap.InfoLevel)
+	s.logs = logs
+
+	s.handler = handler.NewExampleHandler(zap.New(core))
+}
+
+func TestExampleHandler(t *testing.T) {
+	suite.Run(t, new(ExampleHandlerTestSuite))
+}
+
+func (s *ExampleHandlerTestSuite) TestRegister() {
+	app := fiber.New()
+
+	s.handler.Register(app)
+}
+
+func (s *ExampleHandlerTestSuite) TestPing() {
+	app := fiber.New()
+	s.handler.Register(app)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/examples/ping", nil))
+
+	s.NoError(err)
+	s.Equal(fiber.StatusOK, resp.StatusCode)
+	s.Equal(1, s.logs.FilterMessage("ping received").Len())
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"strings"
+
+	gqlhandler "github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/graphql"
+	"github.com/rahmatrdn/go-skeleton/internal/http/middleware"
+	todo_list_usecase "github.com/rahmatrdn/go-skeleton/internal/usecase/todo_list"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+	"go.uber.org/zap"
+)
+
+type GraphQLHandler struct {
+	server      *gqlhandler.Server
+	graphqlOnly bool
+}
+
+// NewGraphQLHandler serves the schema of internal/graphql, its resolvers call
+// the same usecases as the REST handlers. With graphqlOnly the REST routes are
+// turned off, except the auth ones issuing the tokens.
+func NewGraphQLHandler(
+	todoListUsecase todo_list_usecase.ICrudTodoListUsecase,
+	zapLogger *zap.Logger,
+	graphqlOnly bool,
+) *GraphQLHandler {
+	server := gqlhandler.New(graphql.NewExecutableSchema(graphql.Config{
+		Resolvers: graphql.NewResolver(todoListUsecase),
+	}))
+	server.AddTransport(transport.GET{})
+	server.AddTransport(transport.POST{})
+	server.Use(extension.Introspection{})
+	server.SetRecoverFunc(func(ctx context.Context, err interface{}) error {
+		zapLogger.Error("GraphQL resolver panic", zap.Any("panic", err), zap.ByteString("stack", debug.Stack()))
+		return errors.New("internal server error")
+	})
+
+	return &GraphQLHandler{server, graphqlOnly}
+}
+
+func (w *GraphQLHandler) Register(app fiber.Router) {
+	if w.graphqlOnly {
+		app.Use("/api/v1", w.restDisabled)
+	}
+	app.All("/graphql", middleware.VerifyJWTToken, w.Query)
+}
+
+// Query runs a GraphQL request, the resolvers get the authenticated user ID
+// through graphql.UserIDFromContext
+func (w *GraphQLHandler) Query(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(int64)
+
+	fasthttpadaptor.NewFastHTTPHandler(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		w.server.ServeHTTP(rw, r.WithContext(graphql.WithUserID(r.Context(), userID)))
+	}))(c.Context())
+
+	return nil
+}
+
+func (w *GraphQLHandler) restDisabled(c *fiber.Ctx) error {
+	if strings.HasPrefix(c.Path(), "/api/v1/auth/") {
+		return c.Next()
+	}
+
+	return c.Status(http.StatusNotFound).JSON(entity.GeneralResponse{
+		Code:    http.StatusNotFound,
+		Message: "Route Not Found!",
+	})
+}
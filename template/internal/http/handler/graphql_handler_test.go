@@ -0,0 +1,114 @@
+package handler_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rahmatrdn/go-skeleton/internal/http/handler"
+	"github.com/rahmatrdn/go-skeleton/internal/usecase/todo_list/entity"
+	"github.com/rahmatrdn/go-skeleton/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type GraphQLHandlerTestSuite struct {
+	suite.Suite
+	todoListUsecase *mocks.ICrudTodoListUsecase
+	handler         *handler.GraphQLHandler
+	app             *fiber.App
+}
+
+func (s *GraphQLHandlerTestSuite) SetupTest() {
+	s.todoListUsecase = &mocks.ICrudTodoListUsecase{}
+	s.handler = handler.NewGraphQLHandler(s.todoListUsecase, zap.NewNop(), false)
+
+	// Stands in for the auth middleware
+	s.app = fiber.New()
+	s.app.Post("/graphql", func(c *fiber.Ctx) error {
+		if c.Get("X-User-ID") == "1" {
+			c.Locals("user_id", int64(1))
+		}
+		return c.Next()
+	}, s.handler.Query)
+}
+
+func TestGraphQLHandler(t *testing.T) {
+	suite.Run(t, new(GraphQLHandlerTestSuite))
+}
+
+func (s *GraphQLHandlerTestSuite) query(body string, authenticated bool) (int, string) {
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if authenticated {
+		req.Header.Set("X-User-ID", "1")
+	}
+
+	resp, err := s.app.Test(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+
+	return resp.StatusCode, string(content)
+}
+
+func (s *GraphQLHandlerTestSuite) TestRegister() {
+	app := fiber.New()
+
+	s.handler.Register(app)
+}
+
+func (s *GraphQLHandlerTestSuite) TestTodoListsQuery() {
+	s.todoListUsecase.On("GetByUserID", mock.Anything, int64(1)).Return([]*entity.TodoListResponse{
+		{ID: 7, Title: "Write tests"},
+	}, nil).Once()
+
+	code, body := s.query(`{"query":"{ todoLists { id title } }"}`, true)
+
+	s.Equal(http.StatusOK, code)
+	s.JSONEq(`{"data":{"todoLists":[{"id":"7","title":"Write tests"}]}}`, body)
+	s.todoListUsecase.AssertExpectations(s.T())
+}
+
+func (s *GraphQLHandlerTestSuite) TestCreateTodoListMutation() {
+	s.todoListUsecase.On("Create", mock.Anything, entity.TodoListReq{
+		UserID:      1,
+		Title:       "Write tests",
+		Description: "For the GraphQL handler",
+		DoingAt:     "2024-01-02",
+	}).Return(&entity.TodoListResponse{ID: 8, Title: "Write tests"}, nil).Once()
+
+	code, body := s.query(`{"query":"mutation { createTodoList(input: {title: \"Write tests\", description: \"For the GraphQL handler\", doingAt: \"2024-01-02\"}) { id title } }"}`, true)
+
+	s.Equal(http.StatusOK, code)
+	s.JSONEq(`{"data":{"createTodoList":{"id":"8","title":"Write tests"}}}`, body)
+	s.todoListUsecase.AssertExpectations(s.T())
+}
+
+func (s *GraphQLHandlerTestSuite) TestUnauthenticated() {
+	_, body := s.query(`{"query":"{ todoLists { id } }"}`, false)
+
+	s.Contains(body, `"errors"`)
+	s.todoListUsecase.AssertNotCalled(s.T(), "GetByUserID", mock.Anything, mock.Anything)
+}
+
+func (s *GraphQLHandlerTestSuite) TestGraphQLOnlyDisablesREST() {
+	app := fiber.New()
+	handler.NewGraphQLHandler(s.todoListUsecase, zap.NewNop(), true).Register(app)
+	app.Get("/api/v1/todo-lists", func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) })
+	app.Post("/api/v1/auth/login", func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) })
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/todo-lists", nil))
+	s.Require().NoError(err)
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil))
+	s.Require().NoError(err)
+	s.Equal(http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/health"
+
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+type ReadinessHandler struct {
+	checks  map[string]health.Check
+	timeout time.Duration
+}
+
+// NewReadinessHandler reports the service ready once every check passes, each
+// check gets timeout to answer
+func NewReadinessHandler(checks map[string]health.Check, timeout time.Duration) *ReadinessHandler {
+	return &ReadinessHandler{checks, timeout}
+}
+
+func (w *ReadinessHandler) Register(app fiber.Router) {
+	app.Get("/readyz", w.Ready)
+}
+
+// @Summary         Readiness
+// @Description     Whether the service can take traffic: its database answers and has every migration applied
+// @Tags            Health
+// @Produce         json
+// @Success			200 {object} entity.GeneralResponse "Ready"
+// @Failure			503 {object} entity.GeneralResponse "Not ready, data has the error of each failing check"
+// @Router			/readyz [get]
+func (w *ReadinessHandler) Ready(c *fiber.Ctx) error {
+	results := map[string]string{}
+	ready := true
+	for name, err := range health.Run(c.UserContext(), w.checks, w.timeout) {
+		if err != nil {
+			results[name] = err.Error()
+			ready = false
+		} else {
+			results[name] = "ok"
+		}
+	}
+
+	if !ready {
+		return c.Status(http.StatusServiceUnavailable).JSON(entity.GeneralResponse{
+			Code:    http.StatusServiceUnavailable,
+			Message: "Not Ready!",
+			Data:    results,
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(entity.GeneralResponse{
+		Code:    http.StatusOK,
+		Message: "OK!",
+		Data:    results,
+	})
+}
@@ -0,0 +1,115 @@
+package handler_test
+
+import (
+	"database/sql"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rahmatrdn/go-skeleton/internal/health"
+	"github.com/rahmatrdn/go-skeleton/internal/http/handler"
+	"github.com/stretchr/testify/suite"
+	gmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type ReadinessHandlerTestSuite struct {
+	suite.Suite
+	db      *sql.DB
+	mock    sqlmock.Sqlmock
+	handler *handler.ReadinessHandler
+}
+
+func (s *ReadinessHandlerTestSuite) SetupTest() {
+	var err error
+	s.db, s.mock, err = sqlmock.New(sqlmock.MonitorPingsOption(true))
+	s.Require().NoError(err)
+
+	// gorm pings the connection it opens
+	s.mock.ExpectPing()
+	gormDB, err := gorm.Open(gmysql.New(gmysql.Config{Conn: s.db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	s.Require().NoError(err)
+
+	migrations := fstest.MapFS{
+		"000001_create_table_users.up.sql":      {},
+		"000001_create_table_users.down.sql":    {},
+		"000002_create_table_todo_lists.up.sql": {},
+	}
+	s.handler = handler.NewReadinessHandler(map[string]health.Check{
+		"database":   health.Ping(gormDB),
+		"migrations": health.Migrations(gormDB, migrations),
+	}, time.Second)
+}
+
+func (s *ReadinessHandlerTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func TestReadinessHandler(t *testing.T) {
+	suite.Run(t, new(ReadinessHandlerTestSuite))
+}
+
+func (s *ReadinessHandlerTestSuite) ready() (int, string) {
+	app := fiber.New()
+	s.handler.Register(app)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/readyz", nil))
+	s.Require().NoError(err)
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+
+	return resp.StatusCode, string(body)
+}
+
+func (s *ReadinessHandlerTestSuite) expectMigration(version int64, dirty bool) {
+	s.mock.ExpectPing()
+	s.mock.ExpectQuery("SELECT version, dirty FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(version, dirty))
+}
+
+func (s *ReadinessHandlerTestSuite) TestUpToDate() {
+	s.mock.MatchExpectationsInOrder(false)
+	s.expectMigration(2, false)
+
+	status, body := s.ready()
+
+	s.Equal(fiber.StatusOK, status)
+	s.JSONEq(`{"code":200,"message":"OK!","data":{"database":"ok","migrations":"ok"}}`, body)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *ReadinessHandlerTestSuite) TestOutOfDate() {
+	s.mock.MatchExpectationsInOrder(false)
+	s.expectMigration(1, false)
+
+	status, body := s.ready()
+
+	s.Equal(fiber.StatusServiceUnavailable, status)
+	s.JSONEq(`{"code":503,"message":"Not Ready!","data":{"database":"ok","migrations":"migration 1 applied, 2 is the latest"}}`, body)
+}
+
+func (s *ReadinessHandlerTestSuite) TestDirty() {
+	s.mock.MatchExpectationsInOrder(false)
+	s.expectMigration(2, true)
+
+	status, body := s.ready()
+
+	s.Equal(fiber.StatusServiceUnavailable, status)
+	s.Contains(body, `"migrations":"migration 2 is dirty"`)
+}
+
+func (s *ReadinessHandlerTestSuite) TestNothingApplied() {
+	s.mock.MatchExpectationsInOrder(false)
+	s.mock.ExpectPing()
+	s.mock.ExpectQuery("SELECT version, dirty FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}))
+
+	status, body := s.ready()
+
+	s.Equal(fiber.StatusServiceUnavailable, status)
+	s.Contains(body, `"migrations":"migration 0 applied, 2 is the latest"`)
+}
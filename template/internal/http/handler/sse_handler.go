@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"bufio"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/internal/http/middleware"
+	"github.com/rahmatrdn/go-skeleton/internal/realtime"
+
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+type SSEHandler struct {
+	hub       *realtime.Hub
+	heartbeat time.Duration
+}
+
+// NewSSEHandler streams the messages pushed to hub as server-sent events, with
+// a comment frame every heartbeat to keep proxies from closing idle streams
+func NewSSEHandler(hub *realtime.Hub, heartbeat time.Duration) *SSEHandler {
+	return &SSEHandler{hub, heartbeat}
+}
+
+func (w *SSEHandler) Register(app fiber.Router) {
+	app.Get("/events", middleware.VerifyJWTToken, w.Stream)
+}
+
+// Stream keeps the response open and writes each message as a data frame. A
+// client that went away is noticed when writing to it fails, the stream also
+// ends with the server.
+func (w *SSEHandler) Stream(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	userID, _ := c.Locals("user_id").(int64)
+	client := w.hub.Register(userID)
+	done := c.Context().Done()
+
+	c.Context().SetBodyStreamWriter(func(stream *bufio.Writer) {
+		defer w.hub.Unregister(client)
+
+		heartbeat := time.NewTicker(w.heartbeat)
+		defer heartbeat.Stop()
+
+		if writeEvent(stream, ": connected\n\n") != nil {
+			return
+		}
+		for {
+			select {
+			case <-done:
+				return
+			case <-heartbeat.C:
+				if writeEvent(stream, ": heartbeat\n\n") != nil {
+					return
+				}
+			case message, ok := <-client.Send():
+				if !ok {
+					return
+				}
+				if writeEvent(stream, "data: "+string(message)+"\n\n") != nil {
+					return
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+// writeEvent sends frame right away, the error tells the client disconnected
+func writeEvent(stream *bufio.Writer, frame string) error {
+	if _, err := stream.WriteString(frame); err != nil {
+		return err
+	}
+
+	return stream.Flush()
+}
@@ -0,0 +1,117 @@
+package handler_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rahmatrdn/go-skeleton/internal/http/handler"
+	"github.com/rahmatrdn/go-skeleton/internal/queue"
+	"github.com/rahmatrdn/go-skeleton/internal/realtime"
+	"github.com/stretchr/testify/suite"
+)
+
+type SSEHandlerTestSuite struct {
+	suite.Suite
+	hub     *realtime.Hub
+	handler *handler.SSEHandler
+	app     *fiber.App
+	address string
+}
+
+func (s *SSEHandlerTestSuite) SetupTest() {
+	s.hub = realtime.NewHub()
+	s.handler = handler.NewSSEHandler(s.hub, 50*time.Millisecond)
+
+	// The auth middleware is replaced by a user ID taken from the query
+	s.app = fiber.New(fiber.Config{DisableStartupMessage: true})
+	s.app.Get("/events", func(c *fiber.Ctx) error {
+		c.Locals("user_id", int64(c.QueryInt("user_id")))
+		return c.Next()
+	}, s.handler.Stream)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	s.address = listener.Addr().String()
+	go s.app.Listener(listener)
+}
+
+func (s *SSEHandlerTestSuite) TearDownTest() {
+	s.app.Shutdown()
+}
+
+func TestSSEHandler(t *testing.T) {
+	suite.Run(t, new(SSEHandlerTestSuite))
+}
+
+// stream opens the event stream, the returned function reads the next frame
+func (s *SSEHandlerTestSuite) stream(userID int64) (*http.Response, func() string) {
+	resp, err := http.Get(fmt.Sprintf("http://%s/events?user_id=%d", s.address, userID))
+	s.Require().NoError(err)
+	s.Equal("text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	next := func() string {
+		frame := ""
+		for {
+			line, err := reader.ReadString('\n')
+			s.Require().NoError(err)
+			if line == "\n" {
+				return frame
+			}
+			frame += line
+		}
+	}
+
+	return resp, next
+}
+
+func (s *SSEHandlerTestSuite) TestRegister() {
+	app := fiber.New()
+
+	s.handler.Register(app)
+}
+
+func (s *SSEHandlerTestSuite) TestStreamsEventsAndHeartbeats() {
+	resp, next := s.stream(1)
+	defer resp.Body.Close()
+
+	s.Equal(": connected\n", next())
+	s.Equal(1, s.hub.Count())
+
+	q := queue.NewMemoryQueue()
+	defer q.Close()
+	go q.HandleBroadcastDeliveries(realtime.EventTopic, s.hub.HandleEvent)
+
+	s.Require().NoError(realtime.Publish(context.Background(), q, realtime.Event{Type: "todo_list.created", UserID: 1}))
+	s.Require().NoError(realtime.Publish(context.Background(), q, realtime.Event{Type: "maintenance"}))
+
+	events := []string{}
+	heartbeats := 0
+	for len(events) < 2 || heartbeats == 0 {
+		switch frame := next(); frame {
+		case ": heartbeat\n":
+			heartbeats++
+		default:
+			events = append(events, frame)
+		}
+	}
+
+	s.Equal([]string{
+		`data: {"type":"todo_list.created","user_id":1}` + "\n",
+		`data: {"type":"maintenance"}` + "\n",
+	}, events)
+}
+
+func (s *SSEHandlerTestSuite) TestDisconnectUnregisters() {
+	resp, next := s.stream(1)
+	s.Equal(": connected\n", next())
+
+	resp.Body.Close()
+	s.Eventually(func() bool { return s.hub.Count() == 0 }, 2*time.Second, 10*time.Millisecond)
+}
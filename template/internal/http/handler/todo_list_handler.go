@@ -70,7 +70,7 @@ func (w *TodoListHandler) GetByID(c *fiber.Ctx) error {
 // @Failure			401 {object} entity.CustomErrorResponse "Unauthorized"
 // @Failure			422 {object} entity.CustomErrorResponse "Invalid Request Body"
 // @Failure			500 {object} entity.CustomErrorResponse "Internal server Error"
-// @Router			/api/v1/todo-list [get]
+// @Router			/api/v1/todo-lists [get]
 func (w *TodoListHandler) GetByUserID(c *fiber.Ctx) error {
 	userID, err := w.parser.ParserUserID(c)
 	if err != nil {
@@ -96,7 +96,7 @@ func (w *TodoListHandler) GetByUserID(c *fiber.Ctx) error {
 // @Failure			401 {object} entity.CustomErrorResponse "Unauthorized"
 // @Failure			422 {object} entity.CustomErrorResponse "Invalid Request Body"
 // @Failure			500 {object} entity.CustomErrorResponse "Internal server Error"
-// @Router			/api/v1/todo-list [post]
+// @Router			/api/v1/todo-lists [post]
 func (w *TodoListHandler) Create(c *fiber.Ctx) error {
 	var req entity.TodoListReq
 
@@ -125,7 +125,7 @@ func (w *TodoListHandler) Create(c *fiber.Ctx) error {
 // @Failure			401 {object} entity.CustomErrorResponse "Unauthorized"
 // @Failure			422 {object} entity.CustomErrorResponse "Invalid Request Body"
 // @Failure			500 {object} entity.CustomErrorResponse "Internal server Error"
-// @Router			/api/v1/todo-list [put]
+// @Router			/api/v1/todo-lists/{id} [put]
 func (w *TodoListHandler) Update(c *fiber.Ctx) error {
 	var req entity.TodoListReq
 	err := w.parser.ParserBodyWithIntIDPathParamsAndUserID(c, &req)
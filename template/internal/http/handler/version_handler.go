@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/rahmatrdn/go-skeleton/entity"
+
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+type VersionHandler struct {
+	version entity.Version
+}
+
+func NewVersionHandler(version entity.Version) *VersionHandler {
+	return &VersionHandler{version}
+}
+
+func (w *VersionHandler) Register(app fiber.Router) {
+	app.Get("/version", w.Version)
+}
+
+// @Summary         Version
+// @Description     Name, version and commit of the running build
+// @Tags            Health
+// @Produce         json
+// @Success			200 {object} entity.GeneralResponse{data=entity.Version} "Success"
+// @Router			/version [get]
+func (w *VersionHandler) Version(c *fiber.Ctx) error {
+	return c.Status(http.StatusOK).JSON(entity.GeneralResponse{
+		Code:    http.StatusOK,
+		Message: "OK!",
+		Data:    w.version,
+	})
+}
@@ -0,0 +1,48 @@
+package handler_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/http/handler"
+	"github.com/stretchr/testify/suite"
+)
+
+type VersionHandlerTestSuite struct {
+	suite.Suite
+	handler *handler.VersionHandler
+}
+
+func (s *VersionHandlerTestSuite) SetupTest() {
+	s.handler = handler.NewVersionHandler(entity.Version{
+		Name:      "GO SKELETON",
+		Version:   "v1.2.3",
+		Commit:    "abc1234",
+		BuildTime: "2024-05-01T10:00:00Z",
+	})
+}
+
+func TestVersionHandler(t *testing.T) {
+	suite.Run(t, new(VersionHandlerTestSuite))
+}
+
+func (s *VersionHandlerTestSuite) TestRegister() {
+	app := fiber.New()
+
+	s.handler.Register(app)
+}
+
+func (s *VersionHandlerTestSuite) TestVersion() {
+	app := fiber.New()
+	s.handler.Register(app)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/version", nil))
+	s.Require().NoError(err)
+	body, _ := io.ReadAll(resp.Body)
+
+	s.Equal(fiber.StatusOK, resp.StatusCode)
+	s.JSONEq(`{"code":200,"message":"OK!","data":{"name":"GO SKELETON","version":"v1.2.3","commit":"abc1234","build_time":"2024-05-01T10:00:00Z"}}`, string(body))
+}
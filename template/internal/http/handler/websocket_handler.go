@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"github.com/gofiber/contrib/websocket"
+	"github.com/rahmatrdn/go-skeleton/internal/http/middleware"
+	"github.com/rahmatrdn/go-skeleton/internal/realtime"
+
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+type WebSocketHandler struct {
+	hub     *realtime.Hub
+	connect fiber.Handler
+}
+
+// NewWebSocketHandler serves the WebSocket connections of hub, the messages
+// pushed to the hub are written to them
+func NewWebSocketHandler(hub *realtime.Hub) *WebSocketHandler {
+	w := &WebSocketHandler{hub: hub}
+	w.connect = websocket.New(w.serve)
+
+	return w
+}
+
+func (w *WebSocketHandler) Register(app fiber.Router) {
+	app.Get("/ws", w.Upgrade, middleware.VerifyJWTToken, w.Connect)
+}
+
+// Upgrade rejects requests that aren't WebSocket upgrades. Browsers can't set
+// headers on the upgrade, the token may be passed as ?token= instead.
+func (w *WebSocketHandler) Upgrade(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	if token := c.Query("token"); token != "" && c.Get(fiber.HeaderAuthorization) == "" {
+		c.Request().Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+	}
+
+	return c.Next()
+}
+
+// Connect upgrades the request and registers the connection in the hub
+func (w *WebSocketHandler) Connect(c *fiber.Ctx) error {
+	return w.connect(c)
+}
+
+func (w *WebSocketHandler) serve(conn *websocket.Conn) {
+	userID, _ := conn.Locals("user_id").(int64)
+	client := w.hub.Register(userID)
+	defer w.hub.Unregister(client)
+
+	// Clients only listen, reading notices when they disconnect
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case message, ok := <-client.Send():
+			if !ok {
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	fastwebsocket "github.com/fasthttp/websocket"
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/rahmatrdn/go-skeleton/internal/http/handler"
+	"github.com/rahmatrdn/go-skeleton/internal/queue"
+	"github.com/rahmatrdn/go-skeleton/internal/realtime"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebSocketHandlerTestSuite struct {
+	suite.Suite
+	hub     *realtime.Hub
+	handler *handler.WebSocketHandler
+	app     *fiber.App
+	address string
+}
+
+func (s *WebSocketHandlerTestSuite) SetupTest() {
+	s.hub = realtime.NewHub()
+	s.handler = handler.NewWebSocketHandler(s.hub)
+
+	// The auth middleware is replaced by a user ID taken from the query
+	s.app = fiber.New(fiber.Config{DisableStartupMessage: true})
+	s.app.Get("/ws", s.handler.Upgrade, func(c *fiber.Ctx) error {
+		c.Locals("user_id", int64(c.QueryInt("user_id")))
+		return c.Next()
+	}, s.handler.Connect)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	s.address = listener.Addr().String()
+	go s.app.Listener(listener)
+}
+
+func (s *WebSocketHandlerTestSuite) TearDownTest() {
+	s.app.Shutdown()
+}
+
+func TestWebSocketHandler(t *testing.T) {
+	suite.Run(t, new(WebSocketHandlerTestSuite))
+}
+
+func (s *WebSocketHandlerTestSuite) dial(userID int64) *fastwebsocket.Conn {
+	conn, _, err := fastwebsocket.DefaultDialer.Dial(fmt.Sprintf("ws://%s/ws?user_id=%d", s.address, userID), nil)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func (s *WebSocketHandlerTestSuite) read(conn *fastwebsocket.Conn) string {
+	s.Require().NoError(conn.SetReadDeadline(time.Now().Add(2 * time.Second)))
+	_, message, err := conn.ReadMessage()
+	s.Require().NoError(err)
+
+	return string(message)
+}
+
+func (s *WebSocketHandlerTestSuite) TestRegister() {
+	app := fiber.New()
+
+	s.handler.Register(app)
+}
+
+func (s *WebSocketHandlerTestSuite) TestRejectsPlainRequests() {
+	resp, err := s.app.Test(httptest.NewRequest(http.MethodGet, "/ws", nil))
+	s.Require().NoError(err)
+	s.Equal(fiber.StatusUpgradeRequired, resp.StatusCode)
+}
+
+func (s *WebSocketHandlerTestSuite) TestBroadcast() {
+	first, second := s.dial(1), s.dial(2)
+	s.Eventually(func() bool { return s.hub.Count() == 2 }, time.Second, 10*time.Millisecond)
+
+	s.Equal(2, s.hub.Broadcast([]byte("hello")))
+
+	s.Equal("hello", s.read(first))
+	s.Equal("hello", s.read(second))
+}
+
+func (s *WebSocketHandlerTestSuite) TestQueuedEventReachesUser() {
+	other, user := s.dial(1), s.dial(2)
+	s.Eventually(func() bool { return s.hub.Count() == 2 }, time.Second, 10*time.Millisecond)
+
+	q := queue.NewMemoryQueue()
+	defer q.Close()
+	go q.HandleBroadcastDeliveries(realtime.EventTopic, s.hub.HandleEvent)
+
+	s.Require().NoError(realtime.Publish(context.Background(), q, realtime.Event{Type: "todo_list.created", UserID: 2, Data: map[string]interface{}{"id": 7}}))
+	s.JSONEq(`{"type":"todo_list.created","user_id":2,"data":{"id":7}}`, s.read(user))
+
+	s.Require().NoError(realtime.Publish(context.Background(), q, realtime.Event{Type: "maintenance"}))
+	s.JSONEq(`{"type":"maintenance"}`, s.read(other))
+}
+
+func (s *WebSocketHandlerTestSuite) TestDisconnectUnregisters() {
+	conn := s.dial(1)
+	s.Eventually(func() bool { return s.hub.Count() == 1 }, time.Second, 10*time.Millisecond)
+
+	conn.Close()
+	s.Eventually(func() bool { return s.hub.Count() == 0 }, time.Second, 10*time.Millisecond)
+}
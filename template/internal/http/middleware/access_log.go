@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const redactedValue = "[REDACTED]"
+
+type AccessLogConfig struct {
+	// RedactHeaders are request headers logged as [REDACTED], case-insensitive
+	RedactHeaders []string
+	// RedactFields are JSON body fields logged as [REDACTED] at any depth, case-insensitive
+	RedactFields []string
+	// LogHeaders adds the request headers to each entry
+	LogHeaders bool
+	// LogRequestBody adds JSON request bodies to each entry
+	LogRequestBody bool
+	// SampleFirst entries per second are logged for each status class, then
+	// only every SampleThereafter-th one. Sampling is off when SampleFirst is 0.
+	SampleFirst      int
+	SampleThereafter int
+}
+
+var AccessLogConfigDefault = AccessLogConfig{
+	RedactHeaders:    []string{fiber.HeaderAuthorization, fiber.HeaderCookie, fiber.HeaderSetCookie, "X-Api-Key"},
+	RedactFields:     []string{"password", "password_confirmation", "token", "access_token", "refresh_token", "secret"},
+	LogHeaders:       true,
+	LogRequestBody:   false,
+	SampleFirst:      100,
+	SampleThereafter: 100,
+}
+
+// AccessLog logs the method, path, status, latency and request ID of every
+// request. Register it after the requestid middleware so the ID is available.
+func AccessLog(logger *zap.Logger, cfg AccessLogConfig) fiber.Handler {
+	if cfg.SampleFirst > 0 {
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewSamplerWithOptions(core, time.Second, cfg.SampleFirst, cfg.SampleThereafter)
+		}))
+	}
+
+	redactHeaders := lowerSet(cfg.RedactHeaders)
+	redactFields := lowerSet(cfg.RedactFields)
+
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			if fiberErr, ok := err.(*fiber.Error); ok {
+				status = fiberErr.Code
+			} else {
+				status = fiber.StatusInternalServerError
+			}
+		}
+
+		fields := []zap.Field{
+			zap.String("request_id", requestID(c)),
+			zap.String("method", c.Method()),
+			zap.String("path", c.Path()),
+			zap.Int("status", status),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("ip", c.IP()),
+		}
+
+		if cfg.LogHeaders {
+			fields = append(fields, zap.Any("headers", redactHeaderValues(c, redactHeaders)))
+		}
+		if cfg.LogRequestBody {
+			if body, ok := redactBody(c, redactFields); ok {
+				fields = append(fields, zap.Any("body", body))
+			}
+		}
+
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			logger.Error("access", fields...)
+		case status >= fiber.StatusBadRequest:
+			logger.Warn("access", fields...)
+		default:
+			logger.Info("access", fields...)
+		}
+
+		return err
+	}
+}
+
+func requestID(c *fiber.Ctx) string {
+	if id, ok := c.Locals(requestid.ConfigDefault.ContextKey).(string); ok {
+		return id
+	}
+	return c.Get(fiber.HeaderXRequestID)
+}
+
+func redactHeaderValues(c *fiber.Ctx, redact map[string]bool) map[string]string {
+	headers := map[string]string{}
+	for key, value := range c.GetReqHeaders() {
+		if redact[strings.ToLower(key)] {
+			headers[key] = redactedValue
+			continue
+		}
+		headers[key] = strings.Join(value, ", ")
+	}
+	return headers
+}
+
+func redactBody(c *fiber.Ctx, redact map[string]bool) (interface{}, bool) {
+	if !strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEApplicationJSON) || len(c.Body()) == 0 {
+		return nil, false
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(c.Body(), &body); err != nil {
+		return nil, false
+	}
+
+	return redactValue(body, redact), true
+}
+
+func redactValue(value interface{}, redact map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if redact[strings.ToLower(key)] {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValue(field, redact)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item, redact)
+		}
+	}
+	return value
+}
+
+func lowerSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[strings.ToLower(value)] = true
+	}
+	return set
+}
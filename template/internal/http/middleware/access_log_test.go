@@ -0,0 +1,90 @@
+package middleware_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/rahmatrdn/go-skeleton/internal/http/middleware"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type AccessLogTestSuite struct {
+	suite.Suite
+	logs *observer.ObservedLogs
+	app  *fiber.App
+}
+
+func (s *AccessLogTestSuite) SetupTest() {
+	core, logs := observer.New(zap.InfoLevel)
+	s.logs = logs
+
+	cfg := middleware.AccessLogConfigDefault
+	cfg.LogRequestBody = true
+
+	s.app = fiber.New()
+	s.app.Use(requestid.New(), middleware.AccessLog(zap.New(core), cfg))
+	s.app.Post("/login", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+}
+
+func TestAccessLog(t *testing.T) {
+	suite.Run(t, new(AccessLogTestSuite))
+}
+
+func (s *AccessLogTestSuite) TestLogsRequest() {
+	resp, err := s.app.Test(httptest.NewRequest("POST", "/login", nil))
+	s.NoError(err)
+
+	entries := s.logs.All()
+	s.Len(entries, 1)
+
+	fields := entries[0].ContextMap()
+	s.Equal("POST", fields["method"])
+	s.Equal("/login", fields["path"])
+	s.Equal(int64(fiber.StatusOK), fields["status"])
+	s.Equal(resp.Header.Get(fiber.HeaderXRequestID), fields["request_id"])
+	s.NotEmpty(fields["request_id"])
+}
+
+func (s *AccessLogTestSuite) TestRedactsSecrets() {
+	req := httptest.NewRequest("POST", "/login", strings.NewReader(`{"email":"john@example.com","password":"hunter2","nested":{"token":"abc123"}}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer secret-jwt")
+
+	_, err := s.app.Test(req)
+	s.NoError(err)
+
+	s.Len(s.logs.All(), 1)
+	output := fmt.Sprintf("%v", s.logs.All()[0].ContextMap())
+
+	s.NotContains(output, "hunter2")
+	s.NotContains(output, "abc123")
+	s.NotContains(output, "secret-jwt")
+	s.Contains(output, "john@example.com")
+	s.Contains(output, "[REDACTED]")
+}
+
+func (s *AccessLogTestSuite) TestSampling() {
+	core, logs := observer.New(zap.InfoLevel)
+	cfg := middleware.AccessLogConfigDefault
+	cfg.SampleFirst = 2
+	cfg.SampleThereafter = 1000
+
+	app := fiber.New()
+	app.Use(middleware.AccessLog(zap.New(core), cfg))
+	app.Get("/", func(c *fiber.Ctx) error { return nil })
+
+	for i := 0; i < 10; i++ {
+		_, err := app.Test(httptest.NewRequest("GET", "/", nil))
+		s.NoError(err)
+	}
+
+	s.Equal(2, logs.Len())
+}
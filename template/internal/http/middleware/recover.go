@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/gofiber/fiber/v2"
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
+	"go.uber.org/zap"
+)
+
+// PanicLogger publishes the recovered panics, usecase.LogUsecase implements it
+type PanicLogger interface {
+	Error(ctx context.Context, process string, funcName string, err error, logFields map[string]string)
+}
+
+// Recover turns a panic of the next handlers into a 500 with the standard error
+// body. The panic is logged with its stack and the request ID, and published
+// through logUsecase unless it's nil. Errors returned by the handlers, fiber
+// errors included, go to the error handler untouched, and so does a panic with
+// a *fiber.Error.
+func Recover(zapLogger *zap.Logger, logUsecase PanicLogger) fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if fiberErr, ok := recovered.(*fiber.Error); ok {
+				err = fiberErr
+				return
+			}
+
+			panicErr, ok := recovered.(error)
+			if !ok {
+				panicErr = fmt.Errorf("%v", recovered)
+			}
+
+			zapLogger.Error("panic recovered",
+				zap.String("request_id", requestID(c)),
+				zap.String("method", c.Method()),
+				zap.String("path", c.Path()),
+				zap.Error(panicErr),
+				zap.String("stack", string(debug.Stack())),
+			)
+			if logUsecase != nil {
+				logUsecase.Error(helper.WithRequestID(c.UserContext(), requestID(c)), "HTTP Panic", "middleware.Recover", panicErr, map[string]string{
+					"request_id": requestID(c),
+					"method":     c.Method(),
+					"path":       c.Path(),
+				})
+			}
+
+			err = c.Status(apperr.ErrInternal().HTTPCode).JSON(apperr.ErrInternal())
+		}()
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,103 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/rahmatrdn/go-skeleton/internal/http/middleware"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// panicLog records what Recover publishes
+type panicLog struct {
+	errs   []error
+	fields []map[string]string
+}
+
+func (l *panicLog) Error(ctx context.Context, process string, funcName string, err error, logFields map[string]string) {
+	l.errs = append(l.errs, err)
+	l.fields = append(l.fields, logFields)
+}
+
+type RecoverTestSuite struct {
+	suite.Suite
+	logs      *observer.ObservedLogs
+	published *panicLog
+	app       *fiber.App
+}
+
+func (s *RecoverTestSuite) SetupTest() {
+	core, logs := observer.New(zap.InfoLevel)
+	s.logs = logs
+	s.published = &panicLog{}
+
+	s.app = fiber.New()
+	s.app.Use(requestid.New(), middleware.Recover(zap.New(core), s.published))
+	s.app.Get("/panic", func(c *fiber.Ctx) error {
+		var todoLists map[string]int
+		todoLists["first"] = 1
+		return nil
+	})
+	s.app.Get("/fiber-panic", func(c *fiber.Ctx) error {
+		panic(fiber.ErrTeapot)
+	})
+	s.app.Get("/fiber-error", func(c *fiber.Ctx) error {
+		return fiber.ErrForbidden
+	})
+}
+
+func TestRecover(t *testing.T) {
+	suite.Run(t, new(RecoverTestSuite))
+}
+
+func (s *RecoverTestSuite) TestPanicAnswersInternalError() {
+	resp, err := s.app.Test(httptest.NewRequest("GET", "/panic", nil))
+	s.Require().NoError(err)
+
+	body, _ := io.ReadAll(resp.Body)
+	s.Equal(fiber.StatusInternalServerError, resp.StatusCode)
+	s.JSONEq(`{"message":"Something went wrong. Please try again later.","code":"99","error_code":"INTERNAL","http_code":500}`, string(body))
+
+	entries := s.logs.FilterMessage("panic recovered").All()
+	s.Require().Len(entries, 1)
+	fields := entries[0].ContextMap()
+	s.Equal(resp.Header.Get(fiber.HeaderXRequestID), fields["request_id"])
+	s.Equal("/panic", fields["path"])
+	s.Contains(fields["error"], "assignment to entry in nil map")
+	s.Contains(fields["stack"], "recover_test.go")
+
+	s.Require().Len(s.published.errs, 1)
+	s.Contains(s.published.errs[0].Error(), "assignment to entry in nil map")
+	s.Equal(resp.Header.Get(fiber.HeaderXRequestID), s.published.fields[0]["request_id"])
+}
+
+func (s *RecoverTestSuite) TestKeepsFiberErrors() {
+	resp, err := s.app.Test(httptest.NewRequest("GET", "/fiber-error", nil))
+	s.Require().NoError(err)
+	s.Equal(fiber.StatusForbidden, resp.StatusCode)
+
+	resp, err = s.app.Test(httptest.NewRequest("GET", "/fiber-panic", nil))
+	s.Require().NoError(err)
+	s.Equal(fiber.StatusTeapot, resp.StatusCode)
+
+	s.Equal(0, s.logs.Len())
+	s.Empty(s.published.errs)
+}
+
+func (s *RecoverTestSuite) TestWithoutLogUsecase() {
+	app := fiber.New()
+	app.Use(middleware.Recover(zap.NewNop(), nil))
+	app.Get("/panic", func(c *fiber.Ctx) error {
+		panic("boom")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/panic", nil))
+	s.Require().NoError(err)
+	s.Equal(fiber.StatusInternalServerError, resp.StatusCode)
+}
@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rahmatrdn/go-skeleton/config"
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+)
+
+const (
+	HeaderApiKey = "X-API-Key"
+
+	// hashedKeyPrefix marks an API_KEYS entry holding the hex SHA-256 of a key
+	hashedKeyPrefix = "sha256:"
+
+	apiKeyScopesLocal = "api_key_scopes"
+)
+
+// ApiKeys are the keys accepted by an API key middleware, with their scopes
+type ApiKeys struct {
+	keys []apiKey
+}
+
+type apiKey struct {
+	hash [sha256.Size]byte
+	// scopes is nil for a key allowed everything
+	scopes map[string]bool
+}
+
+// ParseApiKeys reads API_KEYS: comma-separated keys, each optionally followed
+// by =scope|scope. A key written as sha256:<hex> is matched by its digest.
+//
+//	API_KEYS=local-key,sha256:9f86d08...=todo:read|todo:write
+func ParseApiKeys(value string) (*ApiKeys, error) {
+	keys := &ApiKeys{}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		secret, scopes, hasScopes := strings.Cut(entry, "=")
+		key := apiKey{}
+
+		if digest, ok := strings.CutPrefix(secret, hashedKeyPrefix); ok {
+			decoded, err := hex.DecodeString(digest)
+			if err != nil || len(decoded) != sha256.Size {
+				return nil, fmt.Errorf("API_KEYS: %q is not a hex SHA-256 digest", digest)
+			}
+			copy(key.hash[:], decoded)
+		} else {
+			key.hash = sha256.Sum256([]byte(secret))
+		}
+
+		if hasScopes {
+			key.scopes = map[string]bool{}
+			for _, scope := range strings.Split(scopes, "|") {
+				if scope = strings.TrimSpace(scope); scope != "" {
+					key.scopes[scope] = true
+				}
+			}
+		}
+
+		keys.keys = append(keys.keys, key)
+	}
+
+	return keys, nil
+}
+
+// lookup finds the key matching secret, comparing digests in constant time
+func (k *ApiKeys) lookup(secret string) (apiKey, bool) {
+	hash := sha256.Sum256([]byte(secret))
+
+	found, match := apiKey{}, false
+	for _, key := range k.keys {
+		if subtle.ConstantTimeCompare(hash[:], key.hash[:]) == 1 {
+			found, match = key, true
+		}
+	}
+	return found, match
+}
+
+// NewVerifyApiKey rejects requests without a known key in the X-API-Key header
+func NewVerifyApiKey(keys *ApiKeys) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		secret := c.Get(HeaderApiKey)
+		if secret == "" {
+			return c.Status(apperr.ErrInvalidApiKey().HTTPCode).JSON(apperr.ErrInvalidApiKey())
+		}
+
+		key, ok := keys.lookup(secret)
+		if !ok {
+			return c.Status(apperr.ErrInvalidApiKey().HTTPCode).JSON(apperr.ErrInvalidApiKey())
+		}
+
+		c.Locals(apiKeyScopesLocal, key.scopes)
+		return c.Next()
+	}
+}
+
+var (
+	verifyApiKey     fiber.Handler
+	verifyApiKeyOnce sync.Once
+)
+
+// VerifyApiKey checks the X-API-Key header against the keys in API_KEYS
+func VerifyApiKey(c *fiber.Ctx) error {
+	verifyApiKeyOnce.Do(func() {
+		keys, err := ParseApiKeys(config.NewConfig().ApiKeys)
+		if err != nil {
+			log.Fatal(err)
+		}
+		verifyApiKey = NewVerifyApiKey(keys)
+	})
+
+	return verifyApiKey(c)
+}
+
+// RequireScope only lets through keys having scope, register it after VerifyApiKey.
+// Keys listed without scopes are allowed everything.
+func RequireScope(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		scopes, ok := c.Locals(apiKeyScopesLocal).(map[string]bool)
+		if !ok || scopes != nil && !scopes[scope] {
+			return c.Status(apperr.ErrMissingScope().HTTPCode).JSON(apperr.ErrMissingScope())
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,76 @@
+package middleware_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rahmatrdn/go-skeleton/internal/http/middleware"
+	"github.com/stretchr/testify/suite"
+)
+
+type VerifyApiKeyTestSuite struct {
+	suite.Suite
+	app *fiber.App
+}
+
+func (s *VerifyApiKeyTestSuite) SetupTest() {
+	hashed := sha256.Sum256([]byte("hashed-key"))
+
+	keys, err := middleware.ParseApiKeys("plain-key, sha256:" + hex.EncodeToString(hashed[:]) + "=todo:read, reader-key=todo:read")
+	s.Require().NoError(err)
+
+	s.app = fiber.New()
+	s.app.Use(middleware.NewVerifyApiKey(keys))
+	s.app.Get("/todo-lists", middleware.RequireScope("todo:read"), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	s.app.Post("/todo-lists", middleware.RequireScope("todo:write"), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusCreated)
+	})
+}
+
+func TestVerifyApiKey(t *testing.T) {
+	suite.Run(t, new(VerifyApiKeyTestSuite))
+}
+
+func (s *VerifyApiKeyTestSuite) request(method, key string) int {
+	req := httptest.NewRequest(method, "/todo-lists", nil)
+	if key != "" {
+		req.Header.Set(middleware.HeaderApiKey, key)
+	}
+
+	resp, err := s.app.Test(req)
+	s.Require().NoError(err)
+	return resp.StatusCode
+}
+
+func (s *VerifyApiKeyTestSuite) TestValidKey() {
+	s.Equal(fiber.StatusOK, s.request("GET", "plain-key"))
+	s.Equal(fiber.StatusOK, s.request("GET", "hashed-key"))
+}
+
+func (s *VerifyApiKeyTestSuite) TestInvalidKey() {
+	s.Equal(fiber.StatusUnauthorized, s.request("GET", "wrong-key"))
+	// The digest itself is not a valid key
+	hashed := sha256.Sum256([]byte("hashed-key"))
+	s.Equal(fiber.StatusUnauthorized, s.request("GET", hex.EncodeToString(hashed[:])))
+}
+
+func (s *VerifyApiKeyTestSuite) TestAbsentKey() {
+	s.Equal(fiber.StatusUnauthorized, s.request("GET", ""))
+}
+
+func (s *VerifyApiKeyTestSuite) TestScopes() {
+	s.Equal(fiber.StatusForbidden, s.request("POST", "reader-key"))
+	s.Equal(fiber.StatusForbidden, s.request("POST", "hashed-key"))
+	// Keys without scopes are allowed everything
+	s.Equal(fiber.StatusCreated, s.request("POST", "plain-key"))
+}
+
+func (s *VerifyApiKeyTestSuite) TestParseApiKeysRejectsBadDigest() {
+	_, err := middleware.ParseApiKeys("sha256:not-hex")
+	s.Error(err)
+}
@@ -0,0 +1,245 @@
+package middleware
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rahmatrdn/go-skeleton/config"
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+)
+
+const (
+	// oidcKeysTTL is how long fetched signing keys are used before fetching them again
+	oidcKeysTTL = time.Hour
+	// oidcMinRefresh limits refetching the keys when a token has an unknown kid
+	oidcMinRefresh = time.Minute
+
+	oidcClaimsLocal = "oidc_claims"
+)
+
+var errUnknownKey = errors.New("unknown signing key")
+
+// OidcVerifier validates bearer tokens issued by an OIDC provider. The signing
+// keys come from the JWKS endpoint of the issuer's discovery document and are
+// cached, a token signed with a new key triggers a refetch.
+type OidcVerifier struct {
+	issuer   string
+	audience string
+	client   *http.Client
+
+	mu   sync.Mutex
+	keys map[string]*rsa.PublicKey
+	// fetchedAt and fetchErr are the time and error of the last fetch, a
+	// failed one included so an unreachable provider is retried every
+	// oidcMinRefresh at most
+	fetchedAt time.Time
+	fetchErr  error
+	// fetching is closed once the fetch in progress is done, nil without one
+	fetching chan struct{}
+}
+
+func NewOidcVerifier(issuer, audience string) *OidcVerifier {
+	return &OidcVerifier{
+		issuer:   strings.TrimSuffix(issuer, "/"),
+		audience: audience,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Verify checks the token signature against the issuer's keys, then its
+// iss, aud and exp claims. exp is required.
+func (v *OidcVerifier) Verify(token string) (*jwt.RegisteredClaims, error) {
+	claims := &jwt.RegisteredClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{"RS256", "RS384", "RS512"}))
+
+	if _, err := parser.ParseWithClaims(token, claims, v.keyFunc); err != nil {
+		return nil, err
+	}
+
+	// Providers like Auth0 end their issuer with a slash, v.issuer has it trimmed
+	if strings.TrimSuffix(claims.Issuer, "/") != v.issuer {
+		return nil, fmt.Errorf("unexpected issuer %q", claims.Issuer)
+	}
+	if !claims.VerifyAudience(v.audience, true) {
+		return nil, fmt.Errorf("token is not issued for audience %q", v.audience)
+	}
+	if claims.ExpiresAt == nil {
+		return nil, errors.New("token has no expiry")
+	}
+
+	return claims, nil
+}
+
+func (v *OidcVerifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	v.mu.Lock()
+	key, ok := v.keys[kid]
+	sinceFetch := time.Since(v.fetchedAt)
+	if ok && sinceFetch <= oidcKeysTTL || sinceFetch < oidcMinRefresh {
+		v.mu.Unlock()
+		// A stale key is used until the provider can be reached again
+		if ok {
+			return key, nil
+		}
+		return nil, errUnknownKey
+	}
+
+	// A single request fetches the keys, outside of the lock so a slow
+	// provider doesn't hold up the requests with a cached key
+	done, fetcher := v.fetching, false
+	if done == nil {
+		done, fetcher = make(chan struct{}), true
+		v.fetching = done
+	}
+	v.mu.Unlock()
+
+	switch {
+	case fetcher:
+		v.refreshKeys(done)
+	case ok:
+		// Keep using the stale key while another request fetches
+		return key, nil
+	default:
+		<-done
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	// A failed fetch leaves the stale keys in place
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	if v.fetchErr != nil {
+		return nil, v.fetchErr
+	}
+	return nil, errUnknownKey
+}
+
+// refreshKeys fetches the keys and wakes the requests waiting on done
+func (v *OidcVerifier) refreshKeys(done chan struct{}) {
+	keys, err := v.fetchKeys()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.fetchedAt, v.fetchErr = time.Now(), err
+	if err == nil {
+		v.keys = keys
+	}
+	v.fetching = nil
+	close(done)
+}
+
+// fetchKeys reads the RSA keys of the JWKS endpoint named in the discovery document
+func (v *OidcVerifier) fetchKeys() (map[string]*rsa.PublicKey, error) {
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JwksURI string `json:"jwks_uri"`
+	}
+	if err := v.getJSON(v.issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != v.issuer {
+		return nil, fmt.Errorf("discovery document is for issuer %q", discovery.Issuer)
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := v.getJSON(discovery.JwksURI, &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" || jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("jwks key %q: %w", jwk.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("jwks key %q: %w", jwk.Kid, err)
+		}
+
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	return keys, nil
+}
+
+func (v *OidcVerifier) getJSON(url string, target interface{}) error {
+	resp, err := v.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// NewVerifyOidcToken rejects requests without a valid `Authorization: Bearer`
+// token. The claims of accepted tokens are available through OidcClaims.
+func NewVerifyOidcToken(verifier *OidcVerifier) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || token == "" {
+			return c.Status(apperr.ErrInvalidToken().HTTPCode).JSON(apperr.ErrInvalidToken())
+		}
+
+		claims, err := verifier.Verify(token)
+		if err != nil {
+			return c.Status(apperr.ErrInvalidToken().HTTPCode).JSON(apperr.ErrInvalidToken())
+		}
+
+		c.Locals(oidcClaimsLocal, claims)
+		return c.Next()
+	}
+}
+
+var (
+	verifyOidcToken     fiber.Handler
+	verifyOidcTokenOnce sync.Once
+)
+
+// VerifyOidcToken validates bearer tokens against OIDC_ISSUER_URL and OIDC_AUDIENCE
+func VerifyOidcToken(c *fiber.Ctx) error {
+	verifyOidcTokenOnce.Do(func() {
+		cfg := config.NewConfig()
+		verifyOidcToken = NewVerifyOidcToken(NewOidcVerifier(cfg.OidcIssuerURL, cfg.OidcAudience))
+	})
+
+	return verifyOidcToken(c)
+}
+
+// OidcClaims returns the claims of the token accepted by VerifyOidcToken
+func OidcClaims(c *fiber.Ctx) (*jwt.RegisteredClaims, bool) {
+	claims, ok := c.Locals(oidcClaimsLocal).(*jwt.RegisteredClaims)
+	return claims, ok
+}
@@ -0,0 +1,228 @@
+package middleware_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/rahmatrdn/go-skeleton/internal/http/middleware"
+	"github.com/stretchr/testify/suite"
+)
+
+const testAudience = "todo-api"
+
+type VerifyOidcTokenTestSuite struct {
+	suite.Suite
+	key      *rsa.PrivateKey
+	provider *httptest.Server
+	app      *fiber.App
+}
+
+func (s *VerifyOidcTokenTestSuite) SetupSuite() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.key = key
+
+	// A fake OIDC provider serving its discovery document and JWKS
+	mux := http.NewServeMux()
+	s.provider = httptest.NewServer(mux)
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   s.provider.URL,
+			"jwks_uri": s.provider.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test-key",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+}
+
+func (s *VerifyOidcTokenTestSuite) TearDownSuite() {
+	s.provider.Close()
+}
+
+func (s *VerifyOidcTokenTestSuite) SetupTest() {
+	s.app = fiber.New()
+	s.app.Use(middleware.NewVerifyOidcToken(middleware.NewOidcVerifier(s.provider.URL, testAudience)))
+	s.app.Get("/me", func(c *fiber.Ctx) error {
+		claims, _ := middleware.OidcClaims(c)
+		return c.SendString(claims.Subject)
+	})
+}
+
+func TestVerifyOidcToken(t *testing.T) {
+	suite.Run(t, new(VerifyOidcTokenTestSuite))
+}
+
+func (s *VerifyOidcTokenTestSuite) claims() jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Issuer:    s.provider.URL,
+		Subject:   "user-1",
+		Audience:  jwt.ClaimStrings{testAudience},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+func (s *VerifyOidcTokenTestSuite) sign(claims jwt.RegisteredClaims, kid string, key *rsa.PrivateKey) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	s.Require().NoError(err)
+	return signed
+}
+
+func (s *VerifyOidcTokenTestSuite) request(token string) *http.Response {
+	req := httptest.NewRequest("GET", "/me", nil)
+	if token != "" {
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+	}
+
+	resp, err := s.app.Test(req)
+	s.Require().NoError(err)
+	return resp
+}
+
+func (s *VerifyOidcTokenTestSuite) TestValidToken() {
+	resp := s.request(s.sign(s.claims(), "test-key", s.key))
+	s.Equal(fiber.StatusOK, resp.StatusCode)
+
+	body := make([]byte, 16)
+	n, _ := resp.Body.Read(body)
+	s.Equal("user-1", string(body[:n]))
+}
+
+func (s *VerifyOidcTokenTestSuite) TestIssuerWithTrailingSlash() {
+	// Auth0 style, the iss claim keeps the slash of the configured issuer URL
+	verifier := middleware.NewOidcVerifier(s.provider.URL+"/", testAudience)
+
+	claims := s.claims()
+	claims.Issuer = s.provider.URL + "/"
+	_, err := verifier.Verify(s.sign(claims, "test-key", s.key))
+	s.NoError(err)
+}
+
+func (s *VerifyOidcTokenTestSuite) TestAbsentToken() {
+	s.Equal(fiber.StatusUnauthorized, s.request("").StatusCode)
+}
+
+func (s *VerifyOidcTokenTestSuite) TestRejectsClaims() {
+	wrongIssuer := s.claims()
+	wrongIssuer.Issuer = "https://other-issuer.example.com"
+
+	wrongAudience := s.claims()
+	wrongAudience.Audience = jwt.ClaimStrings{"other-api"}
+
+	expired := s.claims()
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+
+	noExpiry := s.claims()
+	noExpiry.ExpiresAt = nil
+
+	for name, claims := range map[string]jwt.RegisteredClaims{
+		"issuer":    wrongIssuer,
+		"audience":  wrongAudience,
+		"expired":   expired,
+		"no expiry": noExpiry,
+	} {
+		s.Equal(fiber.StatusUnauthorized, s.request(s.sign(claims, "test-key", s.key)).StatusCode, name)
+	}
+}
+
+func (s *VerifyOidcTokenTestSuite) TestRejectsForeignSignature() {
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+
+	s.Equal(fiber.StatusUnauthorized, s.request(s.sign(s.claims(), "test-key", other)).StatusCode)
+	s.Equal(fiber.StatusUnauthorized, s.request(s.sign(s.claims(), "unknown-key", other)).StatusCode)
+}
+
+func (s *VerifyOidcTokenTestSuite) TestRejectsHmacToken() {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, s.claims())
+	token.Header["kid"] = "test-key"
+	signed, err := token.SignedString([]byte("secret"))
+	s.Require().NoError(err)
+
+	s.Equal(fiber.StatusUnauthorized, s.request(signed).StatusCode)
+}
+
+// countingProvider serves the discovery document of s.provider after delay,
+// or fails when down, counting the requests
+func (s *VerifyOidcTokenTestSuite) countingProvider(delay time.Duration, down bool) (*httptest.Server, *atomic.Int32) {
+	hits := &atomic.Int32{}
+	var provider *httptest.Server
+	provider = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		time.Sleep(delay)
+		if down {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   provider.URL,
+			"jwks_uri": s.provider.URL + "/jwks",
+		})
+	}))
+	s.T().Cleanup(provider.Close)
+	return provider, hits
+}
+
+func (s *VerifyOidcTokenTestSuite) TestUnreachableProviderBacksOff() {
+	provider, hits := s.countingProvider(0, true)
+	verifier := middleware.NewOidcVerifier(provider.URL, testAudience)
+
+	claims := s.claims()
+	claims.Issuer = provider.URL
+	token := s.sign(claims, "test-key", s.key)
+	for i := 0; i < 5; i++ {
+		_, err := verifier.Verify(token)
+		s.Error(err)
+	}
+	s.Equal(int32(1), hits.Load(), "a failed fetch is retried after oidcMinRefresh, not on every request")
+}
+
+func (s *VerifyOidcTokenTestSuite) TestConcurrentRequestsShareOneFetch() {
+	provider, hits := s.countingProvider(100*time.Millisecond, false)
+	verifier := middleware.NewOidcVerifier(provider.URL, testAudience)
+
+	claims := s.claims()
+	claims.Issuer = provider.URL
+	token := s.sign(claims, "test-key", s.key)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := verifier.Verify(token)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		s.NoError(err)
+	}
+	s.Equal(int32(1), hits.Load())
+}
@@ -0,0 +1,127 @@
+package lock_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/internal/lock"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeRedis keeps keys in memory with a clock moved by the test
+type fakeRedis struct {
+	redis.Scripter
+
+	now     time.Time
+	values  map[string]string
+	expires map[string]time.Time
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{now: time.Now(), values: map[string]string{}, expires: map[string]time.Time{}}
+}
+
+func (f *fakeRedis) get(key string) (string, bool) {
+	if expiry, ok := f.expires[key]; ok && !f.now.Before(expiry) {
+		delete(f.values, key)
+		delete(f.expires, key)
+	}
+	value, ok := f.values[key]
+	return value, ok
+}
+
+func (f *fakeRedis) Incr(ctx context.Context, key string) *redis.IntCmd {
+	value, _ := f.get(key)
+	var n int64
+	fmt.Sscan(value, &n)
+	n++
+	f.values[key] = fmt.Sprint(n)
+	return redis.NewIntResult(n, nil)
+}
+
+func (f *fakeRedis) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	if _, ok := f.get(key); ok {
+		return redis.NewBoolResult(false, nil)
+	}
+	f.values[key] = fmt.Sprint(value)
+	f.expires[key] = f.now.Add(expiration)
+	return redis.NewBoolResult(true, nil)
+}
+
+// EvalSha runs the release script: delete the key while it holds the token
+func (f *fakeRedis) EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd {
+	if value, ok := f.get(keys[0]); ok && value == args[0] {
+		delete(f.values, keys[0])
+		return redis.NewCmdResult(int64(1), nil)
+	}
+	return redis.NewCmdResult(int64(0), nil)
+}
+
+type LockTestSuite struct {
+	suite.Suite
+
+	ctx    context.Context
+	redis  *fakeRedis
+	locker *lock.Locker
+}
+
+func (s *LockTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.redis = newFakeRedis()
+	s.locker = lock.NewLocker(s.redis)
+}
+
+func TestLock(t *testing.T) {
+	suite.Run(t, new(LockTestSuite))
+}
+
+func (s *LockTestSuite) TestContention() {
+	held, err := s.locker.Acquire(s.ctx, "report", time.Minute)
+	s.Require().NoError(err)
+
+	_, err = s.locker.Acquire(s.ctx, "report", time.Minute)
+	s.ErrorIs(err, lock.ErrNotAcquired)
+
+	// Other keys are independent
+	_, err = s.locker.Acquire(s.ctx, "cleanup", time.Minute)
+	s.NoError(err)
+
+	s.Require().NoError(held.Release(s.ctx))
+	_, err = s.locker.Acquire(s.ctx, "report", time.Minute)
+	s.NoError(err)
+}
+
+func (s *LockTestSuite) TestExpiry() {
+	first, err := s.locker.Acquire(s.ctx, "report", time.Minute)
+	s.Require().NoError(err)
+
+	s.redis.now = s.redis.now.Add(time.Minute)
+
+	second, err := s.locker.Acquire(s.ctx, "report", time.Minute)
+	s.Require().NoError(err)
+	s.Greater(second.Token, first.Token)
+
+	// The expired owner can't release the lock taken over since
+	s.Require().NoError(first.Release(s.ctx))
+	_, err = s.locker.Acquire(s.ctx, "report", time.Minute)
+	s.ErrorIs(err, lock.ErrNotAcquired)
+}
+
+func (s *LockTestSuite) TestSchedulerKeepsLockUntilExpiry() {
+	scheduler := s.locker.Scheduler(30 * time.Second)
+
+	held, err := scheduler.Lock(s.ctx, "report")
+	s.Require().NoError(err)
+	s.Require().NoError(held.Unlock(s.ctx))
+
+	// A replica firing the same run late still skips it
+	_, err = scheduler.Lock(s.ctx, "report")
+	s.ErrorIs(err, lock.ErrNotAcquired)
+
+	s.redis.now = s.redis.now.Add(30 * time.Second)
+	_, err = scheduler.Lock(s.ctx, "report")
+	s.NoError(err)
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
+	"go.uber.org/zap"
+)
+
+// userIDLocal is the fiber local the auth middleware stores the user ID in
+const userIDLocal = "user_id"
+
+var base atomic.Pointer[zap.Logger]
+
+type userIDKey struct{}
+
+// SetBase sets the logger FromContext builds on, main sets it to its zap
+// logger. Until then FromContext logs nothing.
+func SetBase(zapLogger *zap.Logger) {
+	base.Store(zapLogger)
+}
+
+// Base returns the logger set with SetBase
+func Base() *zap.Logger {
+	if zapLogger := base.Load(); zapLogger != nil {
+		return zapLogger
+	}
+	return zap.NewNop()
+}
+
+// WithUserID returns ctx carrying the ID of the authenticated user. Handlers
+// passing c.Context() don't need it, the fasthttp context holds the ID stored
+// by the auth middleware.
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// FromContext returns the base logger with the request_id and user_id of the
+// request ctx serves, so usecases and repositories log with them without
+// getting a logger injected:
+//
+//	logger.FromContext(ctx).Info("todo list created", zap.Int64("id", todoList.ID))
+//
+// Outside of a request it's the base logger.
+func FromContext(ctx context.Context) *zap.Logger {
+	zapLogger := Base()
+	if requestID := helper.RequestID(ctx); requestID != "" {
+		zapLogger = zapLogger.With(zap.String("request_id", requestID))
+	}
+	if userID := userID(ctx); userID != 0 {
+		zapLogger = zapLogger.With(zap.Int64("user_id", userID))
+	}
+	return zapLogger
+}
+
+func userID(ctx context.Context) int64 {
+	if userID, ok := ctx.Value(userIDKey{}).(int64); ok {
+		return userID
+	}
+
+	userID, _ := ctx.Value(userIDLocal).(int64)
+	return userID
+}
@@ -0,0 +1,72 @@
+package logger_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
+	"github.com/rahmatrdn/go-skeleton/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func observeBase(t *testing.T) *observer.ObservedLogs {
+	core, logs := observer.New(zap.InfoLevel)
+	logger.SetBase(zap.New(core))
+	t.Cleanup(func() { logger.SetBase(nil) })
+	return logs
+}
+
+func TestFromContextCarriesRequestAndUserID(t *testing.T) {
+	logs := observeBase(t)
+
+	app := fiber.New()
+	app.Use(requestid.New())
+	app.Get("/todo-lists", func(c *fiber.Ctx) error {
+		// What the auth middleware does
+		c.Locals("user_id", int64(42))
+
+		logger.FromContext(c.Context()).Info("listing todo lists")
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/todo-lists", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "req-123")
+	_, err := app.Test(req)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "req-123", fields["request_id"])
+	assert.Equal(t, int64(42), fields["user_id"])
+}
+
+func TestFromContextWithContextValues(t *testing.T) {
+	logs := observeBase(t)
+
+	ctx := logger.WithUserID(helper.WithRequestID(context.Background(), "req-456"), 7)
+	logger.FromContext(ctx).Info("consumed")
+
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, map[string]interface{}{"request_id": "req-456", "user_id": int64(7)}, logs.All()[0].ContextMap())
+}
+
+func TestFromContextFallsBackToBase(t *testing.T) {
+	logs := observeBase(t)
+
+	logger.FromContext(context.Background()).Info("started")
+
+	require.Equal(t, 1, logs.Len())
+	assert.Empty(t, logs.All()[0].ContextMap())
+}
+
+func TestBaseWithoutSetBase(t *testing.T) {
+	assert.NotPanics(t, func() {
+		logger.FromContext(context.Background()).Info("dropped")
+	})
+}
@@ -0,0 +1,57 @@
+package metrics_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rahmatrdn/go-skeleton/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// scrape returns the /metrics page serving registry
+func scrape(t *testing.T, registry *prometheus.Registry) string {
+	app := fiber.New()
+	app.Get("/metrics", metrics.Handler(registry))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestSQLPoolMetrics(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(gmysql.New(gmysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	require.NoError(t, err)
+
+	collector, err := metrics.NewSQLCollector("mysql", gormDB)
+	require.NoError(t, err)
+
+	registry := metrics.NewRegistry()
+	registry.MustRegister(collector)
+	page := scrape(t, registry)
+
+	for _, name := range []string{
+		`go_sql_open_connections{db_name="mysql"}`,
+		`go_sql_in_use_connections{db_name="mysql"}`,
+		`go_sql_idle_connections{db_name="mysql"}`,
+		`go_sql_wait_count_total{db_name="mysql"}`,
+		"go_goroutines",
+	} {
+		assert.Contains(t, page, name)
+	}
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// MongoCollector exports the connection pool stats of a MongoDB client. The
+// driver has no pool stats to read, they're counted from the pool events: pass
+// options.Client().SetPoolMonitor(collector.PoolMonitor()) to config.NewMongodb.
+type MongoCollector struct {
+	mu       sync.Mutex
+	open     int64
+	inUse    int64
+	checkout int64
+	failed   int64
+
+	openDesc     *prometheus.Desc
+	inUseDesc    *prometheus.Desc
+	idleDesc     *prometheus.Desc
+	checkoutDesc *prometheus.Desc
+	failedDesc   *prometheus.Desc
+}
+
+func NewMongoCollector() *MongoCollector {
+	return &MongoCollector{
+		openDesc:     prometheus.NewDesc("mongodb_pool_open_connections", "Connections in the pool, in use or idle.", nil, nil),
+		inUseDesc:    prometheus.NewDesc("mongodb_pool_in_use_connections", "Connections checked out of the pool.", nil, nil),
+		idleDesc:     prometheus.NewDesc("mongodb_pool_idle_connections", "Connections waiting in the pool.", nil, nil),
+		checkoutDesc: prometheus.NewDesc("mongodb_pool_checkouts_total", "Connections asked from the pool.", nil, nil),
+		failedDesc:   prometheus.NewDesc("mongodb_pool_checkout_failures_total", "Connections that couldn't be checked out, mostly waits timing out.", nil, nil),
+	}
+}
+
+// PoolMonitor counts the pool events of the client it's given to
+func (c *MongoCollector) PoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{Event: c.handle}
+}
+
+func (c *MongoCollector) handle(evt *event.PoolEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch evt.Type {
+	case event.ConnectionCreated:
+		c.open++
+	case event.ConnectionClosed:
+		c.open--
+	case event.GetStarted:
+		c.checkout++
+	case event.GetSucceeded:
+		c.inUse++
+	case event.GetFailed:
+		c.failed++
+	case event.ConnectionReturned:
+		c.inUse--
+	}
+}
+
+func (c *MongoCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.openDesc
+	ch <- c.inUseDesc
+	ch <- c.idleDesc
+	ch <- c.checkoutDesc
+	ch <- c.failedDesc
+}
+
+func (c *MongoCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch <- prometheus.MustNewConstMetric(c.openDesc, prometheus.GaugeValue, float64(c.open))
+	ch <- prometheus.MustNewConstMetric(c.inUseDesc, prometheus.GaugeValue, float64(c.inUse))
+	ch <- prometheus.MustNewConstMetric(c.idleDesc, prometheus.GaugeValue, float64(c.open-c.inUse))
+	ch <- prometheus.MustNewConstMetric(c.checkoutDesc, prometheus.CounterValue, float64(c.checkout))
+	ch <- prometheus.MustNewConstMetric(c.failedDesc, prometheus.CounterValue, float64(c.failed))
+}
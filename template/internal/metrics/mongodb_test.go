@@ -0,0 +1,35 @@
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/rahmatrdn/go-skeleton/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+func TestMongoPoolMetrics(t *testing.T) {
+	collector := metrics.NewMongoCollector()
+	monitor := collector.PoolMonitor()
+	for _, eventType := range []string{
+		event.ConnectionCreated, event.ConnectionCreated,
+		event.GetStarted, event.GetSucceeded,
+		event.GetStarted, event.GetFailed,
+	} {
+		monitor.Event(&event.PoolEvent{Type: eventType})
+	}
+
+	registry := metrics.NewRegistry()
+	registry.MustRegister(collector)
+	page := scrape(t, registry)
+
+	for _, name := range []string{
+		"mongodb_pool_open_connections 2",
+		"mongodb_pool_in_use_connections 1",
+		"mongodb_pool_idle_connections 1",
+		"mongodb_pool_checkouts_total 2",
+		"mongodb_pool_checkout_failures_total 1",
+	} {
+		assert.Contains(t, page, name)
+	}
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCollector exports the connection pool stats of a Redis client
+type RedisCollector struct {
+	client *redis.Client
+
+	open     *prometheus.Desc
+	inUse    *prometheus.Desc
+	idle     *prometheus.Desc
+	stale    *prometheus.Desc
+	hits     *prometheus.Desc
+	misses   *prometheus.Desc
+	timeouts *prometheus.Desc
+}
+
+func NewRedisCollector(client *redis.Client) *RedisCollector {
+	return &RedisCollector{
+		client:   client,
+		open:     prometheus.NewDesc("redis_pool_open_connections", "Connections in the pool, in use or idle.", nil, nil),
+		inUse:    prometheus.NewDesc("redis_pool_in_use_connections", "Connections currently in use.", nil, nil),
+		idle:     prometheus.NewDesc("redis_pool_idle_connections", "Idle connections.", nil, nil),
+		stale:    prometheus.NewDesc("redis_pool_stale_connections_total", "Stale connections removed from the pool.", nil, nil),
+		hits:     prometheus.NewDesc("redis_pool_hits_total", "Times a free connection was found in the pool.", nil, nil),
+		misses:   prometheus.NewDesc("redis_pool_misses_total", "Times no free connection was found in the pool.", nil, nil),
+		timeouts: prometheus.NewDesc("redis_pool_timeouts_total", "Times waiting for a connection timed out.", nil, nil),
+	}
+}
+
+func (c *RedisCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.open
+	ch <- c.inUse
+	ch <- c.idle
+	ch <- c.stale
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.timeouts
+}
+
+func (c *RedisCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.client.PoolStats()
+
+	ch <- prometheus.MustNewConstMetric(c.open, prometheus.GaugeValue, float64(stats.TotalConns))
+	ch <- prometheus.MustNewConstMetric(c.inUse, prometheus.GaugeValue, float64(stats.TotalConns-stats.IdleConns))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.IdleConns))
+	ch <- prometheus.MustNewConstMetric(c.stale, prometheus.CounterValue, float64(stats.StaleConns))
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.timeouts, prometheus.CounterValue, float64(stats.Timeouts))
+}
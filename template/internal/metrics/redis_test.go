@@ -0,0 +1,27 @@
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/rahmatrdn/go-skeleton/internal/metrics"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisPoolMetrics(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	defer client.Close()
+
+	registry := metrics.NewRegistry()
+	registry.MustRegister(metrics.NewRedisCollector(client))
+	page := scrape(t, registry)
+
+	for _, name := range []string{
+		"redis_pool_open_connections 0",
+		"redis_pool_in_use_connections 0",
+		"redis_pool_idle_connections 0",
+		"redis_pool_timeouts_total 0",
+	} {
+		assert.Contains(t, page, name)
+	}
+}
@@ -0,0 +1,19 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+// NewSQLCollector exports the connection pool stats of db as the go_sql_*
+// metrics labelled with dbName: open, in use and idle connections, and how
+// many times and how long queries waited for one
+func NewSQLCollector(dbName string, db *gorm.DB) (prometheus.Collector, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	return collectors.NewDBStatsCollector(sqlDB, dbName), nil
+}
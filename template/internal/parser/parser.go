@@ -62,7 +62,7 @@ func NewParser() *RequestParser {
 
 // Get User ID from Token
 func (p *RequestParser) ParserUserID(c *fiber.Ctx) (int64, error) {
-	userID := c.Locals("user_id").(int64)
+	userID, _ := c.Locals("user_id").(int64)
 
 	if userID == 0 {
 		return 0, fmt.Errorf("EMPTY USER ID")
@@ -115,7 +115,7 @@ func (p *RequestParser) ParserBodyRequestWithUserID(c *fiber.Ctx, req WithUserID
 		return err
 	}
 
-	userID := c.Locals("user_id").(int64)
+	userID, _ := c.Locals("user_id").(int64)
 
 	req.SetUserID(userID)
 
@@ -127,7 +127,7 @@ func (p *RequestParser) ParserBodyWithIntIDPathParamsAndUserID(c *fiber.Ctx, req
 	if err := p.ParserBodyWithIntIDPathParams(c, req); err != nil {
 		return err
 	}
-	userID := c.Locals("user_id").(int64)
+	userID, _ := c.Locals("user_id").(int64)
 
 	req.SetUserID(userID)
 
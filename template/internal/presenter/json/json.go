@@ -42,25 +42,21 @@ func (p *Json) BuildSuccess(c *fiber.Ctx, data interface{}, message string, code
 }
 
 func (p *Json) BuildError(c *fiber.Ctx, err error) error {
+	// apperr errors, also when wrapped or holding a cause
+	var appErr apperr.CustomErrorResponse
+	if errors.As(err, &appErr) {
+		return c.Status(appErr.HTTPCode).JSON(appErr)
+	}
+
 	unwrappedErr := errors.Unwrap(err)
 
 	if unwrappedErr != nil {
 		errorData := strings.Split(unwrappedErr.Error(), "XX: ")
 
-		if len(errorData) < 2 {
-			return c.Status(apperr.ErrGeneralInvalid().HTTPCode).
-				JSON(apperr.CustomError(err.Error(),
-					entity.BAD_REQUEST_CODE,
-					http.StatusUnprocessableEntity))
-		}
-
-		errorCode := errorData[1]
-		errorMessage := errorData[0]
-
 		// Handle error struct validation
-		if errorCode == entity.INVALID_PAYLOAD_CODE {
+		if len(errorData) == 2 && errorData[1] == entity.INVALID_PAYLOAD_CODE {
 			var errResponse []entity.ErrorResponse
-			json.Unmarshal([]byte(errorMessage), &errResponse)
+			json.Unmarshal([]byte(errorData[0]), &errResponse)
 
 			return c.Status(apperr.ErrGeneralInvalid().HTTPCode).
 				JSON(
@@ -69,14 +65,14 @@ func (p *Json) BuildError(c *fiber.Ctx, err error) error {
 		}
 	}
 
-	switch err := err.(type) {
-	case apperr.CustomErrorResponse:
-		httpCode := err.HTTPCode
-		return c.Status(httpCode).JSON(err)
-	default:
-		return c.Status(apperr.ErrGeneralInvalid().HTTPCode).
-			JSON(apperr.CustomError(err.Error(),
-				entity.BAD_REQUEST_CODE,
-				http.StatusUnprocessableEntity))
+	// Errors wrapped by the repositories and usecases are failures of the
+	// service, the error handler logs their stack and answers a 500
+	if apperr.StackTrace(err) != "" {
+		return err
 	}
+
+	return c.Status(apperr.ErrGeneralInvalid().HTTPCode).
+		JSON(apperr.CustomError(err.Error(),
+			entity.BAD_REQUEST_CODE,
+			http.StatusUnprocessableEntity))
 }
@@ -0,0 +1,117 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
+	mongoRepo "github.com/rahmatrdn/go-skeleton/internal/repository/mongodb"
+	moentity "github.com/rahmatrdn/go-skeleton/internal/repository/mongodb/entity"
+)
+
+// BatchLogQueue is the MongoDB LogConsumer inserting logs with InsertMany. A
+// batch is written once it holds size logs or interval after its first log.
+// ProcessSyncLog returns when the batch of its log is written, so messages are
+// only acked once stored: the batch can't grow past RABBITMQ_CONSUMER_CONCURRENCY.
+type BatchLogQueue struct {
+	ctx          context.Context
+	logMongoRepo mongoRepo.LogRepository
+	size         int
+	interval     time.Duration
+	clock        helper.Clock
+
+	mu      sync.Mutex
+	pending *logBatch
+	closed  bool
+}
+
+type logBatch struct {
+	logs  []moentity.LogCollection
+	timer *time.Timer
+	done  chan struct{}
+	err   error
+}
+
+func NewBatchLogConsumer(
+	ctx context.Context,
+	logMongoRepo mongoRepo.LogRepository,
+	size int,
+	interval time.Duration,
+	clock helper.Clock,
+) *BatchLogQueue {
+	return &BatchLogQueue{ctx: ctx, logMongoRepo: logMongoRepo, size: size, interval: interval, clock: helper.OrRealClock(clock)}
+}
+
+func (l *BatchLogQueue) ProcessSyncLog(payload map[string]interface{}) error {
+	var params entity.Log
+	if err := params.LoadFromMap(payload); err != nil {
+		fmt.Println(fmt.Sprintf("INVALID LOG PAYLOAD: %s", err.Error()))
+
+		return err
+	}
+
+	log := newLogCollection(params, l.clock.Now())
+
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return l.logMongoRepo.Create(l.ctx, log)
+	}
+
+	batch := l.pending
+	if batch == nil {
+		batch = &logBatch{done: make(chan struct{})}
+		batch.timer = time.AfterFunc(l.interval, func() { l.flush(batch) })
+		l.pending = batch
+	}
+	batch.logs = append(batch.logs, log)
+	full := len(batch.logs) >= l.size
+	l.mu.Unlock()
+
+	if full {
+		l.flush(batch)
+	}
+
+	<-batch.done
+	return batch.err
+}
+
+// flush writes batch unless it's already being written
+func (l *BatchLogQueue) flush(batch *logBatch) {
+	l.mu.Lock()
+	if l.pending != batch {
+		l.mu.Unlock()
+		return
+	}
+	l.pending = nil
+	l.mu.Unlock()
+
+	batch.timer.Stop()
+	batch.err = l.logMongoRepo.CreateBatch(l.ctx, batch.logs, 0)
+	if batch.err != nil {
+		fmt.Println("FAILED CREATE LOG BATCH TO MONGODB")
+	} else {
+		fmt.Println(fmt.Sprintf("SYNC SUCCESS! %d logs", len(batch.logs)))
+	}
+	close(batch.done)
+}
+
+// Close writes the pending batch, logs consumed afterwards are inserted one
+// by one. Call it when the worker shuts down.
+func (l *BatchLogQueue) Close() error {
+	l.mu.Lock()
+	l.closed = true
+	batch := l.pending
+	l.mu.Unlock()
+
+	if batch == nil {
+		return nil
+	}
+
+	l.flush(batch)
+	<-batch.done
+	return batch.err
+}
@@ -0,0 +1,92 @@
+package consumer_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/internal/queue/consumer"
+	moentity "github.com/rahmatrdn/go-skeleton/internal/repository/mongodb/entity"
+	"github.com/rahmatrdn/go-skeleton/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type BatchLogConsumerTestSuite struct {
+	suite.Suite
+	ctx  context.Context
+	repo *mocks.LogRepository
+}
+
+func TestBatchLogConsumer(t *testing.T) {
+	suite.Run(t, new(BatchLogConsumerTestSuite))
+}
+
+func (s *BatchLogConsumerTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repo = mocks.NewLogRepository(s.T())
+}
+
+func batchOf(size int) interface{} {
+	return mock.MatchedBy(func(logs []moentity.LogCollection) bool { return len(logs) == size })
+}
+
+// consume handles a log per goroutine, like the worker pool does, and returns their errors
+func (s *BatchLogConsumerTestSuite) consume(logConsumer consumer.LogConsumer, count int) []error {
+	errs := make([]error, count)
+
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = logConsumer.ProcessSyncLog(map[string]interface{}{"status": "ERROR", "func_name": "OrderUsecase.Create"})
+		}(i)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+func (s *BatchLogConsumerTestSuite) TestFlushOnSize() {
+	s.repo.On("CreateBatch", s.ctx, batchOf(3), 0).Return(nil).Once()
+	logConsumer := consumer.NewBatchLogConsumer(s.ctx, s.repo, 3, time.Hour, nil)
+
+	start := time.Now()
+	s.Equal([]error{nil, nil, nil}, s.consume(logConsumer, 3))
+	s.Less(time.Since(start), time.Second)
+}
+
+func (s *BatchLogConsumerTestSuite) TestFlushOnTimeout() {
+	s.repo.On("CreateBatch", s.ctx, batchOf(2), 0).Return(nil).Once()
+	logConsumer := consumer.NewBatchLogConsumer(s.ctx, s.repo, 10, 20*time.Millisecond, nil)
+
+	s.Equal([]error{nil, nil}, s.consume(logConsumer, 2))
+}
+
+func (s *BatchLogConsumerTestSuite) TestFailedBatchFailsEveryLog() {
+	err := errors.New("connection refused")
+	s.repo.On("CreateBatch", s.ctx, batchOf(2), 0).Return(err).Once()
+	logConsumer := consumer.NewBatchLogConsumer(s.ctx, s.repo, 2, time.Hour, nil)
+
+	s.Equal([]error{err, err}, s.consume(logConsumer, 2))
+}
+
+func (s *BatchLogConsumerTestSuite) TestCloseFlushesPendingBatch() {
+	s.repo.On("CreateBatch", s.ctx, batchOf(1), 0).Return(nil).Once()
+	s.repo.On("Create", s.ctx, mock.Anything).Return(nil).Once()
+	logConsumer := consumer.NewBatchLogConsumer(s.ctx, s.repo, 10, time.Hour, nil)
+
+	done := make(chan error)
+	go func() { done <- logConsumer.ProcessSyncLog(map[string]interface{}{"status": "ERROR"}) }()
+
+	// Let the log join the batch, which would otherwise wait an hour
+	time.Sleep(20 * time.Millisecond)
+	s.NoError(logConsumer.Close())
+	s.NoError(<-done)
+
+	// Logs consumed after Close are inserted right away
+	s.NoError(logConsumer.ProcessSyncLog(map[string]interface{}{"status": "ERROR"}))
+}
@@ -0,0 +1,31 @@
+package consumer
+
+import (
+	"fmt"
+
+	"github.com/rahmatrdn/go-skeleton/internal/helper/convert"
+)
+
+// LogConsumer persists logs published to queue.ProcessSyncLog.
+// Implemented by LogQueue (MongoDB) and SqlLogQueue (primary SQL database).
+type LogConsumer interface {
+	ProcessSyncLog(payload map[string]interface{}) error
+}
+
+// executionTime reads the execution_time log field. Logs without one store 0,
+// an invalid one is reported and also stored as 0, its raw value stays in the
+// log fields.
+func executionTime(logFields map[string]string) int {
+	value := logFields["execution_time"]
+	if value == "" {
+		return 0
+	}
+
+	ms, err := convert.ToInt(value)
+	if err != nil {
+		fmt.Println(fmt.Sprintf("INVALID EXECUTION TIME %q: %s", value, err.Error()))
+		return 0
+	}
+
+	return ms
+}
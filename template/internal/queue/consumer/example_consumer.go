@@ -2,15 +2,14 @@ package consumer
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/rahmatrdn/go-skeleton/entity"
 	"github.com/rahmatrdn/go-skeleton/internal/helper"
-	mongoRepo "github.com/rahmatrdn/go-skeleton/internal/repository/mongodb"
 )
 
 type ExampleQueue struct {
-	ctx          context.Context
-	logMongoRepo mongoRepo.LogRepository
+	ctx context.Context
 }
 
 type ExampleConsumer interface {
@@ -19,14 +18,17 @@ type ExampleConsumer interface {
 
 func NewExampleConsumer(
 	ctx context.Context,
-	logMongoRepo mongoRepo.LogRepository,
 ) ExampleConsumer {
-	return &ExampleQueue{ctx, logMongoRepo}
+	return &ExampleQueue{ctx}
 }
 
 func (l *ExampleQueue) Process(payload map[string]interface{}) error {
 	var params entity.Log
-	params.LoadFromMap(payload)
+	if err := params.LoadFromMap(payload); err != nil {
+		fmt.Println(fmt.Sprintf("INVALID LOG PAYLOAD: %s", err.Error()))
+
+		return err
+	}
 
 	helper.Dump(params)
 
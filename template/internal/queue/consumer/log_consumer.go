@@ -14,38 +14,28 @@ import (
 type LogQueue struct {
 	ctx          context.Context
 	logMongoRepo mongoRepo.LogRepository
+	clock        helper.Clock
 }
 
-type LogConsumer interface {
-	ProcessSyncLog(payload map[string]interface{}) error
-}
-
+// NewLogConsumer stores the logs in MongoDB, timestamped by clock (the real
+// clock when nil)
 func NewLogConsumer(
 	ctx context.Context,
 	logMongoRepo mongoRepo.LogRepository,
+	clock helper.Clock,
 ) LogConsumer {
-	return &LogQueue{ctx, logMongoRepo}
+	return &LogQueue{ctx, logMongoRepo, helper.OrRealClock(clock)}
 }
 
 func (l *LogQueue) ProcessSyncLog(payload map[string]interface{}) error {
 	var params entity.Log
-	params.LoadFromMap(payload)
+	if err := params.LoadFromMap(payload); err != nil {
+		fmt.Println(fmt.Sprintf("INVALID LOG PAYLOAD: %s", err.Error()))
 
-	var executionTime string
-	if params.LogFields["execution_time"] != "" {
-		executionTime = params.LogFields["execution_time"]
+		return err
 	}
 
-	err := l.logMongoRepo.Create(l.ctx, moentity.LogCollection{
-		Status:        string(params.Status),
-		FuncName:      params.FuncName,
-		ErrorMessage:  params.ErrorMessage,
-		Process:       params.Process,
-		LogFields:     params.LogFields,
-		Created:       time.Now().UTC().Add(7 * time.Hour),
-		ExecutionTime: helper.ToInt(executionTime),
-	})
-
+	err := l.logMongoRepo.Create(l.ctx, newLogCollection(params, l.clock.Now()))
 	if err != nil {
 		fmt.Println("FAILED CREATE LOG TO MONGODB")
 
@@ -57,3 +47,17 @@ func (l *LogQueue) ProcessSyncLog(payload map[string]interface{}) error {
 
 	return nil
 }
+
+func newLogCollection(params entity.Log, created time.Time) moentity.LogCollection {
+	return moentity.LogCollection{
+		SchemaVersion: moentity.LogSchemaVersion,
+		Status:        string(params.Status),
+		FuncName:      params.FuncName,
+		ErrorMessage:  params.ErrorMessage,
+		Process:       params.Process,
+		LogFields:     params.LogFields,
+		Created:       created,
+		ExecutionTime: executionTime(params.LogFields),
+		RequestID:     params.RequestID,
+	}
+}
@@ -0,0 +1,124 @@
+package consumer_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
+	"github.com/rahmatrdn/go-skeleton/internal/queue"
+	"github.com/rahmatrdn/go-skeleton/internal/queue/consumer"
+	moentity "github.com/rahmatrdn/go-skeleton/internal/repository/mongodb/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/usecase"
+	"github.com/rahmatrdn/go-skeleton/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type LogConsumerTestSuite struct {
+	suite.Suite
+	ctx   context.Context
+	repo  *mocks.LogRepository
+	clock *helper.FakeClock
+}
+
+func TestLogConsumer(t *testing.T) {
+	suite.Run(t, new(LogConsumerTestSuite))
+}
+
+func (s *LogConsumerTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repo = mocks.NewLogRepository(s.T())
+	s.clock = helper.NewFakeClock(time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC))
+}
+
+func (s *LogConsumerTestSuite) process(logFields map[string]interface{}) moentity.LogCollection {
+	var stored moentity.LogCollection
+	s.repo.On("Create", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(moentity.LogCollection)
+	}).Return(nil).Once()
+
+	err := consumer.NewLogConsumer(s.ctx, s.repo, s.clock).ProcessSyncLog(map[string]interface{}{
+		"status":         "INFO",
+		"func_name":      "OrderUsecase.Create",
+		"capture_fields": logFields,
+	})
+	s.Require().NoError(err)
+
+	return stored
+}
+
+func (s *LogConsumerTestSuite) TestExecutionTime() {
+	s.Equal(120, s.process(map[string]interface{}{"execution_time": "120"}).ExecutionTime)
+}
+
+func (s *LogConsumerTestSuite) TestStoresCurrentSchemaVersion() {
+	s.Equal(moentity.LogSchemaVersion, s.process(nil).SchemaVersion)
+}
+
+func (s *LogConsumerTestSuite) TestCreatedByClock() {
+	s.Equal(time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC), s.process(nil).Created)
+
+	s.clock.Advance(time.Minute)
+	s.Equal(time.Date(2025, 7, 1, 10, 1, 0, 0, time.UTC), s.process(nil).Created)
+}
+
+func (s *LogConsumerTestSuite) TestWithoutExecutionTime() {
+	s.Equal(0, s.process(nil).ExecutionTime)
+}
+
+func (s *LogConsumerTestSuite) TestInvalidExecutionTimeKeepsRawValue() {
+	stored := s.process(map[string]interface{}{"execution_time": "120ms"})
+
+	s.Equal(0, stored.ExecutionTime)
+	s.Equal("120ms", stored.LogFields["execution_time"])
+}
+
+func (s *LogConsumerTestSuite) TestMalformedPayloadIsNotStored() {
+	err := consumer.NewLogConsumer(s.ctx, s.repo, s.clock).ProcessSyncLog(map[string]interface{}{
+		"status":    "INFO",
+		"func_name": 42,
+	})
+
+	s.ErrorIs(err, entity.ErrMalformedPayload)
+	s.repo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *LogConsumerTestSuite) TestRequestIDFromHandlerIsStored() {
+	stored := make(chan moentity.LogCollection, 1)
+	s.repo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored <- args.Get(1).(moentity.LogCollection)
+	}).Return(nil).Once()
+
+	q := queue.NewMemoryQueue()
+	defer q.Close()
+	go q.HandleConsumedDeliveries(queue.ProcessSyncLog, consumer.NewLogConsumer(s.ctx, s.repo, s.clock).ProcessSyncLog)
+
+	logUsecase := usecase.NewLogUsecase(q, zap.NewNop())
+	app := fiber.New()
+	app.Use(requestid.New())
+	app.Get("/orders", func(c *fiber.Ctx) error {
+		logUsecase.Error(c.Context(), "checkout", "OrderUsecase.Create", errors.New("stock is empty"), nil)
+		return c.SendStatus(fiber.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "req-123")
+	_, err := app.Test(req)
+	s.Require().NoError(err)
+
+	select {
+	case log := <-stored:
+		s.Equal("req-123", log.RequestID)
+		s.Equal("OrderUsecase.Create", log.FuncName)
+	case <-time.After(time.Second):
+		s.FailNow("log not stored")
+	}
+}
@@ -0,0 +1,62 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
+	sqlRepo "github.com/rahmatrdn/go-skeleton/internal/repository/mysql"
+	sqlentity "github.com/rahmatrdn/go-skeleton/internal/repository/mysql/entity"
+)
+
+// SqlLogQueue is the LogConsumer used when logs are stored in the primary SQL database
+type SqlLogQueue struct {
+	ctx        context.Context
+	logSqlRepo sqlRepo.LogRepository
+	clock      helper.Clock
+}
+
+// NewSqlLogConsumer stores the logs in the SQL database, timestamped by clock
+// (the real clock when nil)
+func NewSqlLogConsumer(
+	ctx context.Context,
+	logSqlRepo sqlRepo.LogRepository,
+	clock helper.Clock,
+) LogConsumer {
+	return &SqlLogQueue{ctx, logSqlRepo, helper.OrRealClock(clock)}
+}
+
+func (l *SqlLogQueue) ProcessSyncLog(payload map[string]interface{}) error {
+	var params entity.Log
+	if err := params.LoadFromMap(payload); err != nil {
+		fmt.Println(fmt.Sprintf("INVALID LOG PAYLOAD: %s", err.Error()))
+
+		return err
+	}
+
+	logFields, _ := json.Marshal(params.LogFields)
+
+	err := l.logSqlRepo.Create(l.ctx, &sqlentity.Log{
+		Status:        string(params.Status),
+		Message:       params.Message,
+		FuncName:      params.FuncName,
+		ErrorMessage:  params.ErrorMessage,
+		Process:       params.Process,
+		LogFields:     string(logFields),
+		Created:       l.clock.Now(),
+		ExecutionTime: executionTime(params.LogFields),
+	})
+
+	if err != nil {
+		fmt.Println("FAILED CREATE LOG TO SQL DATABASE")
+
+		return err
+	}
+
+	fmt.Println("SYNC SUCCESS!")
+	fmt.Println(params)
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/workerpool"
+)
+
+var (
+	ErrQueueFull   = errors.New("queue is full")
+	ErrQueueClosed = errors.New("queue is closed")
+)
+
+// Memory is a Queue passing messages over channels for local development and
+// tests, without a broker. Messages only reach consumers of the same process
+// and the ones not handled yet are lost on Close.
+type Memory struct {
+	RetryCount  int
+	Concurrency int // messages handled at the same time per consumer
+	Buffer      int // messages held per key before Publish fails with ErrQueueFull
+
+	mu       sync.Mutex
+	keys     map[string]chan memoryMessage
+	closed   bool
+	done     chan struct{}
+	handlers sync.WaitGroup
+}
+
+type memoryMessage struct {
+	body     []byte
+	attempts int32
+}
+
+func NewMemoryQueue() *Memory {
+	return &Memory{
+		RetryCount:  3,
+		Concurrency: 1,
+		Buffer:      1000,
+		keys:        map[string]chan memoryMessage{},
+		done:        make(chan struct{}),
+	}
+}
+
+func (m *Memory) Connect() error {
+	return nil
+}
+
+func (m *Memory) Reconnect() error {
+	return nil
+}
+
+// Close stops the consumers and waits for the messages being handled
+func (m *Memory) Close() error {
+	m.mu.Lock()
+	if !m.closed {
+		m.closed = true
+		close(m.done)
+	}
+	m.mu.Unlock()
+
+	m.handlers.Wait()
+
+	return nil
+}
+
+func (m *Memory) BindQueue(key string) (amqp.Queue, error) {
+	m.messages(key)
+
+	return amqp.Queue{Name: key}, nil
+}
+
+// messages returns the channel of key, created on first use
+func (m *Memory) messages(key string) chan memoryMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	messages, ok := m.keys[key]
+	if !ok {
+		messages = make(chan memoryMessage, m.Buffer)
+		m.keys[key] = messages
+	}
+
+	return messages
+}
+
+// HandleConsumedDeliveries handles the messages of key until the queue is closed
+func (m *Memory) HandleConsumedDeliveries(key string, handle func(payload map[string]interface{}) error) {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return
+	}
+	m.handlers.Add(1)
+	m.mu.Unlock()
+	defer m.handlers.Done()
+
+	pool := workerpool.New(context.Background(), m.Concurrency)
+	defer pool.Close()
+
+	messages := m.messages(key)
+	for {
+		select {
+		case <-m.done:
+			return
+		case message := <-messages:
+			if err := pool.Submit(func(ctx context.Context) {
+				m.handleMessage(key, message, handle)
+			}); err != nil {
+				fmt.Println(fmt.Sprintf("[CONSUMER] Failed to handle message: %s", err.Error()))
+			}
+		}
+	}
+}
+
+// HandleBroadcastDeliveries is HandleConsumedDeliveries, the messages never
+// leave the process so its one consumer gets them all
+func (m *Memory) HandleBroadcastDeliveries(key string, handle func(payload map[string]interface{}) error) {
+	m.HandleConsumedDeliveries(key, handle)
+}
+
+func (m *Memory) Publish(ctx context.Context, key string, message []byte, attempts int32) error {
+	if attempts > int32(m.RetryCount) {
+		fmt.Println(fmt.Sprintf("[PUBLISHER] Too many attempts: %s", key))
+		return nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case <-m.done:
+		return ErrQueueClosed
+	default:
+	}
+
+	// Copy the body, the caller may reuse it
+	body := append([]byte(nil), message...)
+
+	select {
+	case m.messages(key) <- memoryMessage{body: body, attempts: attempts}:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+func (m *Memory) handleMessage(key string, message memoryMessage, handle func(payload map[string]interface{}) error) {
+	d, _ := deserialize(message.body)
+	err := handle(d)
+	if err == nil {
+		return
+	}
+
+	fmt.Println(err.Error())
+
+	if errors.Is(err, entity.ErrMalformedPayload) {
+		fmt.Println(fmt.Sprintf("[CONSUMER] Rejected malformed message: %s", key))
+		return
+	}
+
+	if message.attempts < int32(m.RetryCount) {
+		m.Publish(context.Background(), key, message.body, message.attempts+1)
+	} else {
+		fmt.Println(fmt.Sprintf("Too many attempts: %s", key))
+	}
+}
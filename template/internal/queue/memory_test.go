@@ -0,0 +1,109 @@
+package queue_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/queue"
+	"github.com/rahmatrdn/go-skeleton/internal/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type MemoryQueueTestSuite struct {
+	suite.Suite
+	ctx   context.Context
+	queue *queue.Memory
+}
+
+func TestMemoryQueue(t *testing.T) {
+	suite.Run(t, new(MemoryQueueTestSuite))
+}
+
+func (s *MemoryQueueTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.queue = queue.NewMemoryQueue()
+}
+
+func (s *MemoryQueueTestSuite) TearDownTest() {
+	s.NoError(s.queue.Close())
+}
+
+// consume handles key with handle, sending the payloads it got to the returned channel
+func (s *MemoryQueueTestSuite) consume(key string, handle func(payload map[string]interface{}) error) <-chan map[string]interface{} {
+	received := make(chan map[string]interface{}, 10)
+	go s.queue.HandleConsumedDeliveries(key, func(payload map[string]interface{}) error {
+		received <- payload
+		return handle(payload)
+	})
+	return received
+}
+
+func (s *MemoryQueueTestSuite) receive(received <-chan map[string]interface{}) map[string]interface{} {
+	select {
+	case payload := <-received:
+		return payload
+	case <-time.After(time.Second):
+		s.FailNow("no message received")
+		return nil
+	}
+}
+
+func (s *MemoryQueueTestSuite) TestLogUsecaseToConsumer() {
+	received := s.consume(queue.ProcessSyncLog, func(payload map[string]interface{}) error { return nil })
+
+	logUsecase := usecase.NewLogUsecase(s.queue, zap.NewNop())
+	logUsecase.Log(s.ctx, entity.LogError, "Order failed", "OrderUsecase.Create", errors.New("stock is empty"), map[string]string{"order_id": "12"}, "checkout")
+
+	var log entity.Log
+	s.NoError(log.LoadFromMap(s.receive(received)))
+	s.Equal(entity.Log{
+		FuncName:     "OrderUsecase.Create",
+		Message:      "Order failed",
+		ErrorMessage: "stock is empty",
+		Process:      "checkout",
+		Status:       entity.LogError,
+		LogFields:    entity.CaptureFields{"order_id": "12"},
+	}, log)
+}
+
+func (s *MemoryQueueTestSuite) TestRetriesFailedMessages() {
+	s.queue.RetryCount = 2
+	received := s.consume(queue.ProcessExample, func(payload map[string]interface{}) error { return fmt.Errorf("ERROR") })
+
+	s.NoError(s.queue.Publish(s.ctx, queue.ProcessExample, []byte(`{"id":1}`), 1))
+
+	s.Equal(map[string]interface{}{"id": json.Number("1")}, s.receive(received))
+	s.Equal(map[string]interface{}{"id": json.Number("1")}, s.receive(received))
+	s.Never(func() bool { return len(received) > 0 }, 50*time.Millisecond, 10*time.Millisecond)
+}
+
+func (s *MemoryQueueTestSuite) TestDropsMalformedMessages() {
+	received := s.consume(queue.ProcessSyncLog, func(payload map[string]interface{}) error {
+		var log entity.Log
+		return log.LoadFromMap(payload)
+	})
+
+	s.NoError(s.queue.Publish(s.ctx, queue.ProcessSyncLog, []byte(`{"status":"FATAL"}`), 1))
+
+	s.receive(received)
+	s.Never(func() bool { return len(received) > 0 }, 50*time.Millisecond, 10*time.Millisecond)
+}
+
+func (s *MemoryQueueTestSuite) TestPublishFailsWhenFull() {
+	s.queue.Buffer = 1
+
+	s.NoError(s.queue.Publish(s.ctx, queue.ProcessExample, []byte(`{}`), 1))
+	s.ErrorIs(s.queue.Publish(s.ctx, queue.ProcessExample, []byte(`{}`), 1), queue.ErrQueueFull)
+}
+
+func (s *MemoryQueueTestSuite) TestPublishFailsWhenClosed() {
+	s.NoError(s.queue.Close())
+
+	s.ErrorIs(s.queue.Publish(s.ctx, queue.ProcessExample, []byte(`{}`), 1), queue.ErrQueueClosed)
+}
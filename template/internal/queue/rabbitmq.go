@@ -6,8 +6,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
 	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/workerpool"
 )
 
 type Queue interface {
@@ -16,7 +19,8 @@ type Queue interface {
 	BindQueue(key string) (q amqp.Queue, err error)
 	Reconnect() error
 	HandleConsumedDeliveries(key string, handle func(payload map[string]interface{}) error)
-	Publish(key string, message []byte, attempts int32) error
+	HandleBroadcastDeliveries(key string, handle func(payload map[string]interface{}) error)
+	Publish(ctx context.Context, key string, message []byte, attempts int32) error
 }
 
 type MessageBody struct {
@@ -34,63 +38,122 @@ type Message struct {
 }
 
 type RabbitMQ struct {
-	Ctx          context.Context
-	Uri          string
-	Exchange     string
-	Kind         string
-	Prefix       string
-	RetryCount   int
-	Err          chan error
-	conn         *amqp.Connection
-	channel      *amqp.Channel
-	consumerTags map[string]bool
+	Ctx              context.Context
+	Uri              string
+	Exchange         string
+	Kind             string
+	Prefix           string // queue names are Prefix:key
+	RoutingKeyPrefix string // routing keys are RoutingKeyPrefix.key
+	RetryCount       int
+	Concurrency      int          // messages handled at the same time per consumer
+	Err              chan error   // the connection consumed from closed, read by HandleConsumedDeliveries only
+	mu               sync.RWMutex // guards conn, channel and consumerTags, a reconnect replaces them
+	conn             *amqp.Connection
+	channel          *amqp.Channel
+	consumerTags     map[string]bool
+	handlers         sync.WaitGroup
 }
 
 func (c *RabbitMQ) Connect() error {
-	var err error
-	c.conn, err = amqp.Dial(c.Uri)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.connect()
+}
+
+// connect replaces the connection and its channel, closing the previous
+// connection if it's still open, they're left as they were when it fails.
+// c.mu must be held.
+func (c *RabbitMQ) connect() error {
+	if c.conn != nil && !c.conn.IsClosed() {
+		c.conn.Close()
+	}
+
+	conn, err := amqp.Dial(c.Uri)
 	if err != nil {
 		return err
 	}
-	go func() {
-		<-c.conn.NotifyClose(make(chan *amqp.Error)) //Listen to NotifyClose
-		c.Err <- errors.New("BunnyConnection Closed")
-	}()
-	c.consumerTags = make(map[string]bool, 0)
-	c.channel, err = c.conn.Channel()
+	channel, err := conn.Channel()
 	if err != nil {
+		conn.Close()
 		return err
 	}
-	if err := c.channel.ExchangeDeclare(c.Exchange, c.Kind, true, false, false, false, nil); err != nil {
+	if err := channel.ExchangeDeclare(c.Exchange, c.Kind, true, false, false, false, nil); err != nil {
+		conn.Close()
 		return err
 	}
+
+	c.conn, c.channel = conn, channel
+	c.consumerTags = make(map[string]bool, 0)
 	return nil
 }
 
+// connection returns the current connection and its channel, shared by the
+// publishers and the consumers
+func (c *RabbitMQ) connection() (*amqp.Connection, *amqp.Channel) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.conn, c.channel
+}
+
+// Close cancels the consumers and waits for the messages being handled
+// before closing the connection, so they can still be acked
 func (c *RabbitMQ) Close() error {
+	c.mu.RLock()
 	for consumerTag := range c.consumerTags {
-		if err := c.channel.Cancel(consumerTag, true); err != nil {
+		if err := c.channel.Cancel(consumerTag, false); err != nil {
+			c.mu.RUnlock()
 			return err
 		}
 	}
+	c.mu.RUnlock()
+
+	c.handlers.Wait()
 
-	return c.conn.Close()
+	conn, _ := c.connection()
+	return conn.Close()
 }
 
 func (c *RabbitMQ) BindQueue(key string) (q amqp.Queue, err error) {
-	if q, err = c.channel.QueueDeclare(fmt.Sprintf("%s:%s", c.Prefix, key), true, false, false, false, nil); err != nil {
+	return c.bindQueue(key, false)
+}
+
+// bindQueue declares the shared durable queue of key, or for broadcast a
+// server-named queue of this connection only, deleted once it closes
+func (c *RabbitMQ) bindQueue(key string, broadcast bool) (q amqp.Queue, err error) {
+	name, durable := c.QueueName(key), true
+	if broadcast {
+		name, durable = "", false
+	}
+
+	_, channel := c.connection()
+	if q, err = channel.QueueDeclare(name, durable, broadcast, broadcast, false, nil); err != nil {
 		return q, err
 	}
-	if err := c.channel.QueueBind(q.Name, key, c.Exchange, false, nil); err != nil {
+	if err := channel.QueueBind(q.Name, c.RoutingKey(key), c.Exchange, false, nil); err != nil {
 		return q, err
 	}
-	if err := c.channel.Qos(1, 0, false); err != nil {
+	if err := channel.Qos(c.concurrency(), 0, false); err != nil {
 		return q, err
 	}
 
 	return q, nil
 }
 
+// QueueName is the name of the queue declared for the messages of key
+func (c *RabbitMQ) QueueName(key string) string {
+	return fmt.Sprintf("%s:%s", c.Prefix, key)
+}
+
+// RoutingKey is the routing key the messages of key are published with
+func (c *RabbitMQ) RoutingKey(key string) string {
+	if c.RoutingKeyPrefix == "" {
+		return key
+	}
+	return fmt.Sprintf("%s.%s", c.RoutingKeyPrefix, key)
+}
+
 func (c *RabbitMQ) Reconnect() error {
 	if err := c.Connect(); err != nil {
 		return err
@@ -99,60 +162,95 @@ func (c *RabbitMQ) Reconnect() error {
 	return nil
 }
 
+// reconnectFrom replaces failed, unless a publisher or another consumer
+// already did, so one lost connection is only replaced once
+func (c *RabbitMQ) reconnectFrom(failed *amqp.Connection) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn != failed && !c.conn.IsClosed() {
+		return nil
+	}
+	return c.connect()
+}
+
+func (c *RabbitMQ) concurrency() int {
+	if c.Concurrency < 1 {
+		return 1
+	}
+	return c.Concurrency
+}
+
 // Consumer Things
-func (c *RabbitMQ) consume(key string) (<-chan amqp.Delivery, error) {
-	q, err := c.BindQueue(key)
+
+// consume starts consuming key on the current connection, which is returned
+// with the deliveries. c.Err is sent to once that connection closes.
+func (c *RabbitMQ) consume(key string, broadcast bool) (*amqp.Connection, <-chan amqp.Delivery, error) {
+	q, err := c.bindQueue(key, broadcast)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	c.mu.Lock()
+	conn, channel := c.conn, c.channel
 	consumerTag := fmt.Sprintf("ctag:%s", q.Name)
 	c.consumerTags[consumerTag] = true
-	deliveries, err := c.channel.Consume(q.Name, consumerTag, false, false, false, false, nil)
+	c.mu.Unlock()
+
+	deliveries, err := channel.Consume(q.Name, consumerTag, false, false, false, false, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return deliveries, nil
+	go func() {
+		<-conn.NotifyClose(make(chan *amqp.Error, 1)) //Listen to NotifyClose
+		c.Err <- errors.New("BunnyConnection Closed")
+	}()
+
+	return conn, deliveries, nil
 }
 
+// HandleConsumedDeliveries handles the messages of key, the instances running
+// it share the queue so each message is handled once
 func (c *RabbitMQ) HandleConsumedDeliveries(key string, handle func(payload map[string]interface{}) error) {
-	delivery, err := c.consume(key)
+	c.handleDeliveries(key, false, handle)
+}
+
+// HandleBroadcastDeliveries handles every message of key in each instance
+// running it, for state held per process like the realtime hub's clients.
+// Messages published while the instance is disconnected are not kept.
+func (c *RabbitMQ) HandleBroadcastDeliveries(key string, handle func(payload map[string]interface{}) error) {
+	c.handleDeliveries(key, true, handle)
+}
+
+func (c *RabbitMQ) handleDeliveries(key string, broadcast bool, handle func(payload map[string]interface{}) error) {
+	conn, delivery, err := c.consume(key, broadcast)
 	if err != nil {
 		panic(err)
 	}
 
 	for {
-		go handler(*c, key, delivery, handle)
+		c.handlers.Add(1)
+		go c.handler(key, delivery, handle)
 		if err := <-c.Err; err != nil {
 			fmt.Println(fmt.Sprintf("[CONSUMER] RabbitMQ connection closed: %s", err.Error()))
 
-			c.Reconnect()
-			deliveries, err := c.consume(key)
+			c.reconnectFrom(conn)
+			conn, delivery, err = c.consume(key, broadcast)
 			if err != nil {
 				panic(err)
 			}
-			delivery = deliveries
 		}
 	}
 }
 
 // Publisher Things
-func (c *RabbitMQ) Publish(key string, message []byte, attempts int32) error {
+func (c *RabbitMQ) Publish(ctx context.Context, key string, message []byte, attempts int32) error {
 	if attempts > int32(c.RetryCount) {
 		fmt.Println(fmt.Sprintf("[PUBLISHER] Too many attempts: %s", key))
 		return nil
 	}
 
-	select {
-	case err := <-c.Err:
-		if err != nil {
-			fmt.Println(fmt.Sprintf("[PUBLISHER] RabbitMQ connection closed: %s", err.Error()))
-			c.Reconnect()
-		}
-	default:
-	}
-
 	p := amqp.Publishing{
 		ContentType: "application/json",
 		Body:        message,
@@ -163,39 +261,65 @@ func (c *RabbitMQ) Publish(key string, message []byte, attempts int32) error {
 
 	fmt.Println(fmt.Sprintf("[PUBLISHER] Publishing message: %s - %d", key, attempts))
 
-	if err := c.channel.PublishWithContext(c.Ctx, c.Exchange, key, false, false, p); err != nil {
+	conn, channel := c.connection()
+	if err := channel.PublishWithContext(ctx, c.Exchange, c.RoutingKey(key), false, false, p); err != nil {
 		fmt.Println(fmt.Sprintf("[PUBLISHER] Error in publishing message: %s", err.Error()))
 
-		c.Reconnect()
-		return c.Publish(key, message, attempts+1)
+		c.reconnectFrom(conn)
+		return c.Publish(ctx, key, message, attempts+1)
 	}
 
 	fmt.Println(fmt.Sprintf("[PUBLISHER] Published message: %s - %d", key, attempts))
 	return nil
 }
 
-func handler(c RabbitMQ, key string, messages <-chan amqp.Delivery, handle func(payload map[string]interface{}) error) {
-	for message := range messages {
-		fmt.Println(fmt.Sprintf("[*] Received message: %s", key))
-		attempts := int32(1)
+// handler processes deliveries on a worker pool of c.Concurrency goroutines.
+// When the deliveries channel closes, the messages already taken are finished first.
+func (c *RabbitMQ) handler(key string, messages <-chan amqp.Delivery, handle func(payload map[string]interface{}) error) {
+	defer c.handlers.Done()
+
+	pool := workerpool.New(context.Background(), c.concurrency())
+	defer pool.Close()
 
-		if message.Headers["x-attempts"] != nil {
-			attempts = message.Headers["x-attempts"].(int32)
+	for message := range messages {
+		message := message
+		if err := pool.Submit(func(ctx context.Context) {
+			c.handleMessage(key, message, handle)
+		}); err != nil {
+			fmt.Println(fmt.Sprintf("[CONSUMER] Failed to handle message: %s", err.Error()))
+			message.Nack(false, true)
 		}
+	}
+}
 
-		d, _ := deserialize(message.Body)
-		err := handle(d)
+func (c *RabbitMQ) handleMessage(key string, message amqp.Delivery, handle func(payload map[string]interface{}) error) {
+	fmt.Println(fmt.Sprintf("[*] Received message: %s", key))
+	attempts := int32(1)
 
-		message.Ack(false)
+	if message.Headers["x-attempts"] != nil {
+		attempts = message.Headers["x-attempts"].(int32)
+	}
 
-		if err != nil {
-			fmt.Println(err.Error())
+	d, _ := deserialize(message.Body)
+	err := handle(d)
 
-			if attempts < int32(c.RetryCount) {
-				c.Publish(key, message.Body, attempts+int32(1))
-			} else {
-				fmt.Println(fmt.Sprintf("Too many attempts: %s", key))
-			}
+	// A malformed message fails on every attempt, drop it (or dead-letter it
+	// when the queue has a dead letter exchange) instead of retrying
+	if errors.Is(err, entity.ErrMalformedPayload) {
+		fmt.Println(fmt.Sprintf("[CONSUMER] Rejected malformed message: %s", err.Error()))
+		message.Nack(false, false)
+		return
+	}
+
+	message.Ack(false)
+
+	if err != nil {
+		fmt.Println(err.Error())
+
+		if attempts < int32(c.RetryCount) {
+			c.Publish(c.Ctx, key, message.Body, attempts+int32(1))
+		} else {
+			fmt.Println(fmt.Sprintf("Too many attempts: %s", key))
 		}
 	}
 }
@@ -0,0 +1,154 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/queue"
+)
+
+// EventTopic is the queue key of the events pushed to WebSocket and SSE clients
+const EventTopic = "realtime.event"
+
+// Event is a backend event sent to the connected clients. Without a UserID it
+// goes to every client, otherwise only to the connections of that user.
+type Event struct {
+	Type   string      `json:"type"`
+	UserID int64       `json:"user_id,omitempty"`
+	Data   interface{} `json:"data,omitempty"`
+}
+
+// Client is a connection registered in the Hub, the WebSocket and SSE handlers
+// write the messages of Send to it
+type Client struct {
+	UserID int64
+	send   chan []byte
+}
+
+// Send returns the messages to write to the connection, closed on Unregister
+func (c *Client) Send() <-chan []byte {
+	return c.send
+}
+
+// Hub is the registry of the connected clients
+type Hub struct {
+	// Buffer is the number of messages held per client, a client falling further
+	// behind misses messages instead of slowing the others down
+	Buffer int
+
+	mu      sync.RWMutex
+	clients map[*Client]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		Buffer:  64,
+		clients: map[*Client]struct{}{},
+	}
+}
+
+func (h *Hub) Register(userID int64) *Client {
+	client := &Client{UserID: userID, send: make(chan []byte, h.Buffer)}
+
+	h.mu.Lock()
+	h.clients[client] = struct{}{}
+	h.mu.Unlock()
+
+	return client
+}
+
+func (h *Hub) Unregister(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.clients[client]; ok {
+		delete(h.clients, client)
+		close(client.send)
+	}
+}
+
+// Count returns the number of connected clients
+func (h *Hub) Count() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.clients)
+}
+
+// Broadcast sends message to every client and returns how many got it
+func (h *Hub) Broadcast(message []byte) int {
+	return h.send(message, func(*Client) bool { return true })
+}
+
+// SendToUser sends message to the clients of userID and returns how many got it
+func (h *Hub) SendToUser(userID int64, message []byte) int {
+	return h.send(message, func(c *Client) bool { return c.UserID == userID })
+}
+
+func (h *Hub) send(message []byte, to func(*Client) bool) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	sent := 0
+	for client := range h.clients {
+		if !to(client) {
+			continue
+		}
+		select {
+		case client.send <- message:
+			sent++
+		default:
+			fmt.Println(fmt.Sprintf("REALTIME CLIENT OF USER %d IS TOO SLOW, MESSAGE DROPPED", client.UserID))
+		}
+	}
+
+	return sent
+}
+
+// Push sends event to its clients
+func (h *Hub) Push(event Event) error {
+	message, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if event.UserID != 0 {
+		h.SendToUser(event.UserID, message)
+	} else {
+		h.Broadcast(message)
+	}
+
+	return nil
+}
+
+// HandleEvent pushes the events consumed from EventTopic, run it with
+// queue.HandleBroadcastDeliveries(realtime.EventTopic, hub.HandleEvent) so each
+// API replica gets the events of its own clients
+func (h *Hub) HandleEvent(payload map[string]interface{}) error {
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	var event Event
+	if err := json.Unmarshal(content, &event); err != nil || event.Type == "" {
+		fmt.Println(fmt.Sprintf("INVALID REALTIME EVENT: %s", string(content)))
+		return fmt.Errorf("%w: realtime event needs a type", entity.ErrMalformedPayload)
+	}
+
+	return h.Push(event)
+}
+
+// Publish queues event for the hubs consuming EventTopic, backend code (usecases,
+// workers, the scheduler) uses it to reach the WebSocket and SSE clients
+func Publish(ctx context.Context, q queue.Queue, event Event) error {
+	message, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return q.Publish(ctx, EventTopic, message, 1)
+}
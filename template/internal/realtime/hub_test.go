@@ -0,0 +1,48 @@
+package realtime_test
+
+import (
+	"testing"
+
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/realtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHubSendToUser(t *testing.T) {
+	hub := realtime.NewHub()
+	first, second := hub.Register(1), hub.Register(2)
+
+	assert.Equal(t, 1, hub.SendToUser(2, []byte("hello")))
+	assert.Equal(t, "hello", string(<-second.Send()))
+	assert.Empty(t, first.Send())
+}
+
+func TestHubDropsMessagesOfSlowClients(t *testing.T) {
+	hub := realtime.NewHub()
+	hub.Buffer = 1
+	hub.Register(1)
+
+	assert.Equal(t, 1, hub.Broadcast([]byte("first")))
+	assert.Equal(t, 0, hub.Broadcast([]byte("second")))
+}
+
+func TestHubUnregisterClosesSend(t *testing.T) {
+	hub := realtime.NewHub()
+	client := hub.Register(1)
+
+	hub.Unregister(client)
+	hub.Unregister(client)
+
+	_, ok := <-client.Send()
+	assert.False(t, ok)
+	assert.Equal(t, 0, hub.Count())
+}
+
+func TestHubHandleEventRejectsEventsWithoutType(t *testing.T) {
+	hub := realtime.NewHub()
+	client := hub.Register(1)
+
+	assert.ErrorIs(t, hub.HandleEvent(map[string]interface{}{"data": "x"}), entity.ErrMalformedPayload)
+	assert.NoError(t, hub.HandleEvent(map[string]interface{}{"type": "ping", "user_id": float64(1)}))
+	assert.JSONEq(t, `{"type":"ping","user_id":1}`, string(<-client.Send()))
+}
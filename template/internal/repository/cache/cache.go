@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrCacheMiss is returned by Cache.Get for keys that aren't cached
+var ErrCacheMiss = errors.New("cache miss")
+
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// RedisCache is the Cache of the repository decorators, backed by Redis
+type RedisCache struct {
+	client *redis.Client
+}
+
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{client}
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCacheMiss
+	}
+	return value, err
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	return c.client.Del(ctx, keys...).Err()
+}
+
+// Aside returns the cached value of key, or loads it and caches it for ttl.
+// The cache is best effort: when it fails the value is loaded from the
+// repository and the request goes on.
+func Aside[T any](ctx context.Context, cache Cache, key string, ttl time.Duration, load func(ctx context.Context) (T, error)) (T, error) {
+	funcName := "cache.Aside"
+	captureFieldError := entity.CaptureFields{"key": key}
+
+	cached, err := cache.Get(ctx, key)
+	if err == nil {
+		var value T
+		if err := json.Unmarshal(cached, &value); err == nil {
+			return value, nil
+		}
+	} else if !errors.Is(err, ErrCacheMiss) {
+		helper.LogError("cache.Get", funcName, err, captureFieldError, "")
+	}
+
+	value, err := load(ctx)
+	if err != nil {
+		return value, err
+	}
+
+	encoded, err := json.Marshal(value)
+	if err == nil {
+		err = cache.Set(ctx, key, encoded, ttl)
+	}
+	if err != nil {
+		helper.LogError("cache.Set", funcName, err, captureFieldError, "")
+	}
+
+	return value, nil
+}
+
+// invalidate deletes keys after a write, a failure is logged as the cached
+// values expire with their TTL anyway
+func invalidate(ctx context.Context, cache Cache, keys ...string) {
+	if err := cache.Delete(ctx, keys...); err != nil {
+		helper.LogError("cache.Delete", "cache.invalidate", err, entity.CaptureFields{"keys": strings.Join(keys, ",")}, "")
+	}
+}
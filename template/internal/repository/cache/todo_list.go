@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql"
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql/entity"
+)
+
+// TodoListRepository caches the reads of a mysql.ITodoListRepository and
+// invalidates them on writes. Begin and LockByID go straight to the database.
+type TodoListRepository struct {
+	mysql.ITodoListRepository
+	cache Cache
+	ttl   time.Duration
+}
+
+func NewTodoListRepository(repo mysql.ITodoListRepository, cache Cache, ttl time.Duration) *TodoListRepository {
+	return &TodoListRepository{repo, cache, ttl}
+}
+
+func todoListKey(ID int64) string {
+	return fmt.Sprintf("todo_list:%d", ID)
+}
+
+func userTodoListsKey(userID int64) string {
+	return fmt.Sprintf("todo_list:user:%d", userID)
+}
+
+func (r *TodoListRepository) GetByUserID(ctx context.Context, userID int64) ([]*entity.TodoList, error) {
+	return Aside(ctx, r.cache, userTodoListsKey(userID), r.ttl, func(ctx context.Context) ([]*entity.TodoList, error) {
+		return r.ITodoListRepository.GetByUserID(ctx, userID)
+	})
+}
+
+func (r *TodoListRepository) GetByID(ctx context.Context, ID int64) (*entity.TodoList, error) {
+	return Aside(ctx, r.cache, todoListKey(ID), r.ttl, func(ctx context.Context) (*entity.TodoList, error) {
+		return r.ITodoListRepository.GetByID(ctx, ID)
+	})
+}
+
+func (r *TodoListRepository) Create(ctx context.Context, dbTrx mysql.TrxObj, params *entity.TodoList, nonZeroVal bool) error {
+	if err := r.ITodoListRepository.Create(ctx, dbTrx, params, nonZeroVal); err != nil {
+		return err
+	}
+
+	invalidate(ctx, r.cache, todoListKey(params.ID), userTodoListsKey(params.UserID))
+	return nil
+}
+
+func (r *TodoListRepository) CreateBatch(ctx context.Context, dbTrx mysql.TrxObj, items []*entity.TodoList, batchSize int) error {
+	if err := r.ITodoListRepository.CreateBatch(ctx, dbTrx, items, batchSize); err != nil {
+		return err
+	}
+
+	keys, users := []string{}, map[int64]bool{}
+	for _, item := range items {
+		keys = append(keys, todoListKey(item.ID))
+		if !users[item.UserID] {
+			users[item.UserID] = true
+			keys = append(keys, userTodoListsKey(item.UserID))
+		}
+	}
+	if len(keys) > 0 {
+		invalidate(ctx, r.cache, keys...)
+	}
+	return nil
+}
+
+func (r *TodoListRepository) Update(ctx context.Context, dbTrx mysql.TrxObj, params *entity.TodoList, changes *entity.TodoList) error {
+	if err := r.ITodoListRepository.Update(ctx, dbTrx, params, changes); err != nil {
+		return err
+	}
+
+	keys := []string{todoListKey(params.ID), userTodoListsKey(params.UserID)}
+	if changes != nil && changes.UserID != 0 && changes.UserID != params.UserID {
+		keys = append(keys, userTodoListsKey(changes.UserID))
+	}
+	invalidate(ctx, r.cache, keys...)
+	return nil
+}
+
+// DeleteByID reads the todo list first to know whose cached lists to invalidate
+func (r *TodoListRepository) DeleteByID(ctx context.Context, dbTrx mysql.TrxObj, ID int64) error {
+	existing, err := r.ITodoListRepository.GetByID(ctx, ID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.ITodoListRepository.DeleteByID(ctx, dbTrx, ID); err != nil {
+		return err
+	}
+
+	keys := []string{todoListKey(ID)}
+	if existing != nil {
+		keys = append(keys, userTodoListsKey(existing.UserID))
+	}
+	invalidate(ctx, r.cache, keys...)
+	return nil
+}
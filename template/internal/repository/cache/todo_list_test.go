@@ -0,0 +1,131 @@
+package cache_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/internal/repository/cache"
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql/entity"
+	"github.com/rahmatrdn/go-skeleton/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const ttl = 5 * time.Minute
+
+type TodoListCacheTestSuite struct {
+	suite.Suite
+
+	ctx   context.Context
+	repo  *mocks.ITodoListRepository
+	cache *mocks.Cache
+	todo  *entity.TodoList
+
+	cachedRepo *cache.TodoListRepository
+}
+
+func (s *TodoListCacheTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repo = mocks.NewITodoListRepository(s.T())
+	s.cache = mocks.NewCache(s.T())
+	s.todo = &entity.TodoList{ID: 7, UserID: 3, Title: "Write tests"}
+
+	s.cachedRepo = cache.NewTodoListRepository(s.repo, s.cache, ttl)
+}
+
+func TestTodoListCache(t *testing.T) {
+	suite.Run(t, new(TodoListCacheTestSuite))
+}
+
+func (s *TodoListCacheTestSuite) TestGetByIDHit() {
+	cached, _ := json.Marshal(s.todo)
+	s.cache.On("Get", s.ctx, "todo_list:7").Return(cached, nil).Once()
+
+	result, err := s.cachedRepo.GetByID(s.ctx, 7)
+	s.NoError(err)
+	s.Equal(s.todo.Title, result.Title)
+	s.repo.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
+}
+
+func (s *TodoListCacheTestSuite) TestGetByIDMiss() {
+	cached, _ := json.Marshal(s.todo)
+	s.cache.On("Get", s.ctx, "todo_list:7").Return(nil, cache.ErrCacheMiss).Once()
+	s.repo.On("GetByID", s.ctx, int64(7)).Return(s.todo, nil).Once()
+	s.cache.On("Set", s.ctx, "todo_list:7", cached, ttl).Return(nil).Once()
+
+	result, err := s.cachedRepo.GetByID(s.ctx, 7)
+	s.NoError(err)
+	s.Equal(s.todo, result)
+}
+
+func (s *TodoListCacheTestSuite) TestGetByUserIDMiss() {
+	todos := []*entity.TodoList{s.todo}
+	cached, _ := json.Marshal(todos)
+	s.cache.On("Get", s.ctx, "todo_list:user:3").Return(nil, cache.ErrCacheMiss).Once()
+	s.repo.On("GetByUserID", s.ctx, int64(3)).Return(todos, nil).Once()
+	s.cache.On("Set", s.ctx, "todo_list:user:3", cached, ttl).Return(nil).Once()
+
+	result, err := s.cachedRepo.GetByUserID(s.ctx, 3)
+	s.NoError(err)
+	s.Equal(todos, result)
+}
+
+func (s *TodoListCacheTestSuite) TestCacheErrorFallsBackToRepository() {
+	s.cache.On("Get", s.ctx, "todo_list:7").Return(nil, fmt.Errorf("connection refused")).Once()
+	s.repo.On("GetByID", s.ctx, int64(7)).Return(s.todo, nil).Once()
+	s.cache.On("Set", s.ctx, "todo_list:7", mock.Anything, ttl).Return(fmt.Errorf("connection refused")).Once()
+
+	result, err := s.cachedRepo.GetByID(s.ctx, 7)
+	s.NoError(err)
+	s.Equal(s.todo, result)
+}
+
+func (s *TodoListCacheTestSuite) TestRepositoryErrorIsNotCached() {
+	s.cache.On("Get", s.ctx, "todo_list:7").Return(nil, cache.ErrCacheMiss).Once()
+	s.repo.On("GetByID", s.ctx, int64(7)).Return(nil, fmt.Errorf("ERROR")).Once()
+
+	_, err := s.cachedRepo.GetByID(s.ctx, 7)
+	s.Error(err)
+	s.cache.AssertNotCalled(s.T(), "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TodoListCacheTestSuite) TestCreateInvalidates() {
+	s.repo.On("Create", s.ctx, nil, s.todo, true).Return(nil).Once()
+	s.cache.On("Delete", s.ctx, "todo_list:7", "todo_list:user:3").Return(nil).Once()
+
+	s.NoError(s.cachedRepo.Create(s.ctx, nil, s.todo, true))
+}
+
+func (s *TodoListCacheTestSuite) TestCreateBatchInvalidates() {
+	items := []*entity.TodoList{s.todo, {ID: 8, UserID: 3}, {ID: 9, UserID: 4}}
+	s.repo.On("CreateBatch", s.ctx, nil, items, 2).Return(nil).Once()
+	s.cache.On("Delete", s.ctx, "todo_list:7", "todo_list:user:3", "todo_list:8", "todo_list:9", "todo_list:user:4").Return(nil).Once()
+
+	s.NoError(s.cachedRepo.CreateBatch(s.ctx, nil, items, 2))
+}
+
+func (s *TodoListCacheTestSuite) TestUpdateInvalidates() {
+	changes := &entity.TodoList{UserID: 4}
+	s.repo.On("Update", s.ctx, nil, s.todo, changes).Return(nil).Once()
+	s.cache.On("Delete", s.ctx, "todo_list:7", "todo_list:user:3", "todo_list:user:4").Return(nil).Once()
+
+	s.NoError(s.cachedRepo.Update(s.ctx, nil, s.todo, changes))
+}
+
+func (s *TodoListCacheTestSuite) TestFailedWriteKeepsCache() {
+	s.repo.On("Update", s.ctx, nil, s.todo, (*entity.TodoList)(nil)).Return(fmt.Errorf("ERROR")).Once()
+
+	s.Error(s.cachedRepo.Update(s.ctx, nil, s.todo, nil))
+	s.cache.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (s *TodoListCacheTestSuite) TestDeleteInvalidates() {
+	s.repo.On("GetByID", s.ctx, int64(7)).Return(s.todo, nil).Once()
+	s.repo.On("DeleteByID", s.ctx, nil, int64(7)).Return(nil).Once()
+	s.cache.On("Delete", s.ctx, "todo_list:7", "todo_list:user:3").Return(nil).Once()
+
+	s.NoError(s.cachedRepo.DeleteByID(s.ctx, nil, 7))
+}
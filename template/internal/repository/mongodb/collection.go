@@ -2,3 +2,8 @@ package mongodb
 
 const SampleCollection = "sample_meta"
 const LogCollection = "logs"
+const UserCollection = "users"
+const TodoListCollection = "todo_lists"
+
+// CounterCollection holds one sequence per collection, see reserveIDs
+const CounterCollection = "counters"
@@ -2,7 +2,13 @@ package entity
 
 import "time"
 
+// LogSchemaVersion is the shape of the LogCollection documents written now, bump
+// it with a step in the mongodb logMigrations when the shape changes. Documents
+// written before the field existed have no schema_version and are version 1.
+const LogSchemaVersion = 2
+
 type LogCollection struct {
+	SchemaVersion int               `bson:"schema_version" json:"schema_version"`
 	Status        string            `bson:"status" json:"status"`
 	Message       string            `bson:"message" json:"message"`
 	FuncName      string            `bson:"func_name" json:"func_name"`
@@ -11,9 +17,10 @@ type LogCollection struct {
 	LogFields     map[string]string `bson:"log_fields" json:"log_fields"`
 	Created       time.Time         `bson:"created" json:"created"`
 	ExecutionTime int               `bson:"exec_time" json:"exec_time"`
+	RequestID     string            `bson:"request_id,omitempty" json:"request_id,omitempty"`
 }
 
 func NewLogCollection() LogCollection {
-	instance := LogCollection{}
+	instance := LogCollection{SchemaVersion: LogSchemaVersion}
 	return instance
 }
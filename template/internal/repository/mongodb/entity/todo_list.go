@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type TodoListCollection struct {
+	ID          int64     `bson:"_id" json:"id"`
+	UserID      int64     `bson:"user_id" json:"user_id"`
+	Title       string    `bson:"title" json:"title"`
+	Description string    `bson:"description" json:"description"`
+	DoingAt     time.Time `bson:"doing_at" json:"doing_at"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+}
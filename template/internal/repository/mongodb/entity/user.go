@@ -0,0 +1,10 @@
+package entity
+
+type UserCollection struct {
+	ID       int64  `bson:"_id" json:"id"`
+	Email    string `bson:"email" json:"email"`
+	Phone    string `bson:"phone" json:"phone"`
+	Password string `bson:"password" json:"-"`
+	Name     string `bson:"name" json:"name"`
+	Role     int8   `bson:"role" json:"role"`
+}
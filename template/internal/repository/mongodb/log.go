@@ -3,14 +3,25 @@ package mongodb
 import (
 	"context"
 
-	errwrap "github.com/pkg/errors"
+	apperr "github.com/rahmatrdn/go-skeleton/error"
 	"github.com/rahmatrdn/go-skeleton/internal/helper"
 	"github.com/rahmatrdn/go-skeleton/internal/repository/mongodb/entity"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// LogIndexes are the indexes of the log collection, created by EnsureIndexes.
+// Add new ones here, renaming an index creates it next to the old one.
+var LogIndexes = []mongo.IndexModel{
+	{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created", Value: -1}}, Options: options.Index().SetName("status_created")},
+	{Keys: bson.D{{Key: "func_name", Value: 1}}, Options: options.Index().SetName("func_name")},
+	{Keys: bson.D{{Key: "created", Value: -1}}, Options: options.Index().SetName("created")},
+}
+
 type LogRepository interface {
 	Create(ctx context.Context, params entity.LogCollection) error
+	CreateBatch(ctx context.Context, items []entity.LogCollection, batchSize int) error
 }
 
 type Log struct {
@@ -21,13 +32,84 @@ func NewLogRepository(db *mongo.Database) *Log {
 	return &Log{collection: db.Collection(LogCollection)}
 }
 
+// EnsureIndexes creates the LogIndexes missing from the collection, call it at startup
+func (r *Log) EnsureIndexes(ctx context.Context) error {
+	funcName := "[LogRepositoryMongo.EnsureIndexes]"
+
+	if _, err := r.collection.Indexes().CreateMany(ctx, LogIndexes); err != nil {
+		return apperr.Wrap(err, funcName)
+	}
+
+	return nil
+}
+
 func (r *Log) Create(ctx context.Context, params entity.LogCollection) error {
 	funcName := "[LogRepositoryMongo.Create]"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
-		return errwrap.Wrap(err, funcName)
+		return apperr.Wrap(err, funcName)
 	}
 
 	_, err := r.collection.InsertOne(ctx, params)
-	return err
+	return apperr.Wrap(err, funcName)
+}
+
+// Find returns the logs matching filter, documents of older schema versions are
+// upgraded to the current LogCollection as they are read
+func (r *Log) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) ([]entity.LogCollection, error) {
+	funcName := "[LogRepositoryMongo.Find]"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, apperr.Wrap(err, funcName)
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, apperr.Wrap(err, funcName)
+	}
+	defer cursor.Close(ctx)
+
+	logs := []entity.LogCollection{}
+	for cursor.Next(ctx) {
+		log, err := UpgradeLog(cursor.Current)
+		if err != nil {
+			return nil, apperr.Wrap(err, funcName)
+		}
+		logs = append(logs, log)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, apperr.Wrap(err, funcName)
+	}
+
+	return logs, nil
+}
+
+// CreateBatch inserts items with one InsertMany per batchSize documents, all
+// of them at once when batchSize isn't positive
+func (r *Log) CreateBatch(ctx context.Context, items []entity.LogCollection, batchSize int) error {
+	funcName := "[LogRepositoryMongo.CreateBatch]"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return apperr.Wrap(err, funcName)
+	}
+
+	if batchSize <= 0 {
+		batchSize = len(items)
+	}
+
+	for start := 0; start < len(items); start += batchSize {
+		batch := items[start:min(start+batchSize, len(items))]
+
+		documents := make([]interface{}, len(batch))
+		for i, item := range batch {
+			documents[i] = item
+		}
+
+		if _, err := r.collection.InsertMany(ctx, documents); err != nil {
+			return apperr.Wrap(err, funcName)
+		}
+	}
+
+	return nil
 }
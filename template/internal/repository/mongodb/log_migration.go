@@ -0,0 +1,62 @@
+package mongodb
+
+import (
+	"fmt"
+
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mongodb/entity"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// logMigrations upgrade a raw log document by one schema version, the step at
+// index i takes a version i+1 document to version i+2. Old documents are
+// upgraded when read instead of rewriting the collection: append a step here
+// when LogCollection changes shape and bump entity.LogSchemaVersion.
+var logMigrations = []func(doc bson.M){
+	// 1 → 2: schema_version is added, the fields are unchanged
+	func(doc bson.M) {},
+}
+
+// UpgradeLog decodes a log document of any known schema version into the
+// current LogCollection
+func UpgradeLog(raw bson.Raw) (entity.LogCollection, error) {
+	var log entity.LogCollection
+
+	doc := bson.M{}
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return log, err
+	}
+
+	version := logSchemaVersion(doc)
+	if version < 1 || version > entity.LogSchemaVersion {
+		return log, fmt.Errorf("unknown log schema version %d, the latest is %d", version, entity.LogSchemaVersion)
+	}
+
+	for ; version < entity.LogSchemaVersion; version++ {
+		logMigrations[version-1](doc)
+	}
+	doc["schema_version"] = entity.LogSchemaVersion
+
+	upgraded, err := bson.Marshal(doc)
+	if err != nil {
+		return log, err
+	}
+
+	err = bson.Unmarshal(upgraded, &log)
+	return log, err
+}
+
+// logSchemaVersion reads schema_version, documents without it are version 1
+func logSchemaVersion(doc bson.M) int {
+	switch version := doc["schema_version"].(type) {
+	case int32:
+		return int(version)
+	case int64:
+		return int(version)
+	case float64:
+		return int(version)
+	case nil:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,133 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mongodb"
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mongodb/entity"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func logs(count int) []entity.LogCollection {
+	items := []entity.LogCollection{}
+	for i := 0; i < count; i++ {
+		items = append(items, entity.LogCollection{Status: "info", Message: "imported"})
+	}
+	return items
+}
+
+// insertedBatches returns the number of documents of each insert command sent
+func insertedBatches(mt *mtest.T) []int {
+	batches := []int{}
+	for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+		if event.CommandName != "insert" {
+			continue
+		}
+		documents, _ := event.Command.Lookup("documents").Array().Values()
+		batches = append(batches, len(documents))
+	}
+	return batches
+}
+
+func TestLogCreateBatch(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("splits the documents into InsertMany batches", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		err := mongodb.NewLogRepository(mt.DB).CreateBatch(context.Background(), logs(3), 2)
+		assert.NoError(mt, err)
+		assert.Equal(mt, []int{2, 1}, insertedBatches(mt))
+	})
+
+	mt.Run("inserts everything at once without a batch size", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := mongodb.NewLogRepository(mt.DB).CreateBatch(context.Background(), logs(3), 0)
+		assert.NoError(mt, err)
+		assert.Equal(mt, []int{3}, insertedBatches(mt))
+	})
+
+	mt.Run("stops at the first failed batch", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 11000, Message: "duplicate key"}))
+
+		err := mongodb.NewLogRepository(mt.DB).CreateBatch(context.Background(), logs(3), 2)
+		assert.Error(mt, err)
+		assert.Equal(mt, []int{2}, insertedBatches(mt))
+	})
+
+	mt.Run("sends nothing for no documents", func(mt *mtest.T) {
+		err := mongodb.NewLogRepository(mt.DB).CreateBatch(context.Background(), nil, 2)
+		assert.NoError(mt, err)
+		assert.Empty(mt, insertedBatches(mt))
+	})
+}
+
+func TestLogEnsureIndexes(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("requests the declared indexes", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		err := mongodb.NewLogRepository(mt.DB).EnsureIndexes(context.Background())
+		assert.NoError(mt, err)
+
+		event := mt.GetStartedEvent()
+		assert.Equal(mt, "createIndexes", event.CommandName)
+		assert.Equal(mt, mongodb.LogCollection, event.Command.Lookup("createIndexes").StringValue())
+
+		indexes, _ := event.Command.Lookup("indexes").Array().Values()
+		requested := map[string]string{}
+		for _, index := range indexes {
+			document := index.Document()
+			requested[document.Lookup("name").StringValue()] = document.Lookup("key").String()
+		}
+		assert.Equal(mt, map[string]string{
+			"status_created": `{"status": {"$numberInt":"1"},"created": {"$numberInt":"-1"}}`,
+			"func_name":      `{"func_name": {"$numberInt":"1"}}`,
+			"created":        `{"created": {"$numberInt":"-1"}}`,
+		}, requested)
+	})
+
+	mt.Run("returns the server error", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 85, Message: "index options conflict"}))
+
+		err := mongodb.NewLogRepository(mt.DB).EnsureIndexes(context.Background())
+		assert.Error(mt, err)
+	})
+}
+
+func TestLogFind(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	namespace := "test." + mongodb.LogCollection
+
+	mt.Run("upgrades documents of older schema versions", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, namespace, mtest.FirstBatch,
+			bson.D{{Key: "status", Value: "info"}, {Key: "func_name", Value: "v1"}, {Key: "exec_time", Value: 12}},
+			bson.D{{Key: "schema_version", Value: entity.LogSchemaVersion}, {Key: "status", Value: "error"}, {Key: "func_name", Value: "current"}},
+		))
+
+		logs, err := mongodb.NewLogRepository(mt.DB).Find(context.Background(), bson.M{})
+		assert.NoError(mt, err)
+		assert.Equal(mt, []entity.LogCollection{
+			{SchemaVersion: entity.LogSchemaVersion, Status: "info", FuncName: "v1", ExecutionTime: 12},
+			{SchemaVersion: entity.LogSchemaVersion, Status: "error", FuncName: "current"},
+		}, logs)
+	})
+
+	mt.Run("rejects documents of a newer schema version", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, namespace, mtest.FirstBatch,
+			bson.D{{Key: "schema_version", Value: entity.LogSchemaVersion + 1}, {Key: "status", Value: "info"}},
+		))
+
+		_, err := mongodb.NewLogRepository(mt.DB).Find(context.Background(), bson.M{})
+		assert.ErrorContains(mt, err, "unknown log schema version")
+	})
+}
@@ -0,0 +1,209 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mongodb/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql"
+	mentity "github.com/rahmatrdn/go-skeleton/internal/repository/mysql/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TodoListRepository is the mysql.ITodoListRepository of MongoDB projects, the
+// todo lists are stored in TodoListCollection with the int64 IDs of the SQL table
+type TodoListRepository struct {
+	noTrxSupport
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewTodoListRepository(db *mongo.Database) *TodoListRepository {
+	return &TodoListRepository{db: db, collection: db.Collection(TodoListCollection)}
+}
+
+func (r *TodoListRepository) GetByUserID(ctx context.Context, userID int64) ([]*mentity.TodoList, error) {
+	funcName := "[TodoListRepositoryMongo.GetByUserID]"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, apperr.Wrap(err, funcName)
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, apperr.Wrap(err, funcName)
+	}
+	defer cursor.Close(ctx)
+
+	result := []*mentity.TodoList{}
+	for cursor.Next(ctx) {
+		var document entity.TodoListCollection
+		if err := cursor.Decode(&document); err != nil {
+			return nil, apperr.Wrap(err, funcName)
+		}
+		result = append(result, toTodoList(document))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, apperr.Wrap(err, funcName)
+	}
+
+	return result, nil
+}
+
+// GetByID returns nil without an error when there's no todo list with ID, like
+// the SQL repository
+func (r *TodoListRepository) GetByID(ctx context.Context, ID int64) (*mentity.TodoList, error) {
+	funcName := "[TodoListRepositoryMongo.GetByID]"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, apperr.Wrap(err, funcName)
+	}
+
+	var document entity.TodoListCollection
+	err := r.collection.FindOne(ctx, bson.M{"_id": ID}).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, apperr.Wrap(err, funcName)
+	}
+
+	return toTodoList(document), nil
+}
+
+// Create inserts params and sets its ID, every field is stored so nonZeroVal
+// changes nothing
+func (r *TodoListRepository) Create(ctx context.Context, dbTrx mysql.TrxObj, params *mentity.TodoList, nonZeroVal bool) error {
+	return apperr.Wrap(r.CreateBatch(ctx, dbTrx, []*mentity.TodoList{params}, 1), "[TodoListRepositoryMongo.Create]")
+}
+
+// CreateBatch inserts items with one InsertMany per batchSize documents, all
+// of them at once when batchSize isn't positive, and sets their IDs
+func (r *TodoListRepository) CreateBatch(ctx context.Context, dbTrx mysql.TrxObj, items []*mentity.TodoList, batchSize int) error {
+	funcName := "[TodoListRepositoryMongo.CreateBatch]"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return apperr.Wrap(err, funcName)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+	if batchSize <= 0 {
+		batchSize = len(items)
+	}
+
+	firstID, err := reserveIDs(ctx, r.db, TodoListCollection, len(items))
+	if err != nil {
+		return apperr.Wrap(err, funcName)
+	}
+	for i, item := range items {
+		item.ID = firstID + int64(i)
+	}
+
+	for start := 0; start < len(items); start += batchSize {
+		batch := items[start:min(start+batchSize, len(items))]
+
+		documents := make([]interface{}, len(batch))
+		for i, item := range batch {
+			documents[i] = fromTodoList(item)
+		}
+
+		if _, err := r.collection.InsertMany(ctx, documents); err != nil {
+			return apperr.Wrap(err, funcName)
+		}
+	}
+
+	return nil
+}
+
+// LockByID returns the todo list of ID, MongoDB has no row locks to take
+func (r *TodoListRepository) LockByID(ctx context.Context, dbTrx mysql.TrxObj, ID int64) (*mentity.TodoList, error) {
+	return r.GetByID(ctx, ID)
+}
+
+// Update sets the non-zero fields of changes on the todo list of params, every
+// field of params without changes
+func (r *TodoListRepository) Update(ctx context.Context, dbTrx mysql.TrxObj, params *mentity.TodoList, changes *mentity.TodoList) error {
+	funcName := "[TodoListRepositoryMongo.Update]"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return apperr.Wrap(err, funcName)
+	}
+
+	set := bson.M{}
+	if changes == nil {
+		set = bson.M{
+			"user_id":     params.UserID,
+			"title":       params.Title,
+			"description": params.Description,
+			"doing_at":    params.DoingAt,
+			"created_at":  params.CreatedAt,
+			"updated_at":  params.UpdatedAt,
+		}
+	} else {
+		if changes.UserID != 0 {
+			set["user_id"] = changes.UserID
+		}
+		if changes.Title != "" {
+			set["title"] = changes.Title
+		}
+		if changes.Description != "" {
+			set["description"] = changes.Description
+		}
+		if !changes.DoingAt.IsZero() {
+			set["doing_at"] = changes.DoingAt
+		}
+		if !changes.CreatedAt.IsZero() {
+			set["created_at"] = changes.CreatedAt
+		}
+		if !changes.UpdatedAt.IsZero() {
+			set["updated_at"] = changes.UpdatedAt
+		}
+	}
+	if len(set) == 0 {
+		return nil
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": params.ID}, bson.M{"$set": set})
+	return apperr.Wrap(err, funcName)
+}
+
+func (r *TodoListRepository) DeleteByID(ctx context.Context, dbTrx mysql.TrxObj, id int64) error {
+	funcName := "[TodoListRepositoryMongo.DeleteByID]"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return apperr.Wrap(err, funcName)
+	}
+
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return apperr.Wrap(err, funcName)
+}
+
+func toTodoList(document entity.TodoListCollection) *mentity.TodoList {
+	return &mentity.TodoList{
+		ID:          document.ID,
+		UserID:      document.UserID,
+		Title:       document.Title,
+		Description: document.Description,
+		DoingAt:     document.DoingAt,
+		CreatedAt:   document.CreatedAt,
+		UpdatedAt:   document.UpdatedAt,
+	}
+}
+
+func fromTodoList(todoList *mentity.TodoList) entity.TodoListCollection {
+	return entity.TodoListCollection{
+		ID:          todoList.ID,
+		UserID:      todoList.UserID,
+		Title:       todoList.Title,
+		Description: todoList.Description,
+		DoingAt:     todoList.DoingAt,
+		CreatedAt:   todoList.CreatedAt,
+		UpdatedAt:   todoList.UpdatedAt,
+	}
+}
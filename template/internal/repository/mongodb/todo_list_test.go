@@ -0,0 +1,82 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mongodb"
+	mentity "github.com/rahmatrdn/go-skeleton/internal/repository/mysql/entity"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestTodoListCreateBatch(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("reserves one ID per item and inserts them in batches", func(mt *mtest.T) {
+		mt.AddMockResponses(counterResponse(12), mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		items := []*mentity.TodoList{{Title: "a"}, {Title: "b"}, {Title: "c"}}
+		err := mongodb.NewTodoListRepository(mt.DB).CreateBatch(context.Background(), nil, items, 2)
+		assert.NoError(mt, err)
+		assert.Equal(mt, []int64{10, 11, 12}, []int64{items[0].ID, items[1].ID, items[2].ID})
+		assert.Equal(mt, []int{2, 1}, insertedBatches(mt))
+	})
+
+	mt.Run("sends nothing for no items", func(mt *mtest.T) {
+		err := mongodb.NewTodoListRepository(mt.DB).CreateBatch(context.Background(), nil, nil, 2)
+		assert.NoError(mt, err)
+		assert.Nil(mt, mt.GetStartedEvent())
+	})
+}
+
+func TestTodoListGetByID(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	namespace := "test." + mongodb.TodoListCollection
+
+	mt.Run("returns the todo list", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, namespace, mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: int64(5)}, {Key: "user_id", Value: int64(1)}, {Key: "title", Value: "Groceries"}},
+		))
+
+		todoList, err := mongodb.NewTodoListRepository(mt.DB).GetByID(context.Background(), 5)
+		assert.NoError(mt, err)
+		assert.Equal(mt, &mentity.TodoList{ID: 5, UserID: 1, Title: "Groceries"}, todoList)
+	})
+
+	mt.Run("returns nil without a match, like the SQL repository", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, namespace, mtest.FirstBatch))
+
+		todoList, err := mongodb.NewTodoListRepository(mt.DB).GetByID(context.Background(), 5)
+		assert.NoError(mt, err)
+		assert.Nil(mt, todoList)
+	})
+}
+
+func TestTodoListUpdate(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("sets the non-zero fields of changes", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		err := mongodb.NewTodoListRepository(mt.DB).Update(context.Background(), nil,
+			&mentity.TodoList{ID: 5, Title: "Groceries", Description: "Milk"},
+			&mentity.TodoList{Title: "Shopping", UpdatedAt: updatedAt})
+		assert.NoError(mt, err)
+
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		assert.Equal(mt, int64(5), update.Lookup("q", "_id").Int64())
+		set := update.Lookup("u", "$set").Document()
+		elements, _ := set.Elements()
+		assert.Len(mt, elements, 2)
+		assert.Equal(mt, "Shopping", set.Lookup("title").StringValue())
+		assert.Equal(mt, updatedAt, set.Lookup("updated_at").Time().UTC())
+	})
+}
@@ -0,0 +1,48 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// noTrxSupport lets the MongoDB repositories stand in for the SQL ones, their
+// writes aren't transactional so Begin returns a TrxObj doing nothing
+type noTrxSupport struct{}
+
+type noTrxObj struct{}
+
+func (noTrxSupport) Begin() (mysql.TrxObj, error) {
+	return noTrxObj{}, nil
+}
+
+func (noTrxObj) Commit() error {
+	return nil
+}
+
+func (noTrxObj) Rollback() error {
+	return nil
+}
+
+// reserveIDs reserves count consecutive int64 IDs of collection in
+// CounterCollection, like the AUTO_INCREMENT ids of the SQL tables, and returns
+// the first of them
+func reserveIDs(ctx context.Context, db *mongo.Database, collection string, count int) (int64, error) {
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+
+	err := db.Collection(CounterCollection).FindOneAndUpdate(ctx,
+		bson.M{"_id": collection},
+		bson.M{"$inc": bson.M{"seq": count}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	if err != nil {
+		return 0, err
+	}
+
+	return counter.Seq - int64(count) + 1, nil
+}
@@ -0,0 +1,95 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mongodb/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql"
+	mentity "github.com/rahmatrdn/go-skeleton/internal/repository/mysql/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// User is the mysql.UserRepository of MongoDB projects, the users are stored
+// in UserCollection with the int64 IDs of the SQL table
+type User struct {
+	noTrxSupport
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewUserRepository(db *mongo.Database) *User {
+	return &User{db: db, collection: db.Collection(UserCollection)}
+}
+
+func (u *User) Create(ctx context.Context, dbTrx mysql.TrxObj, user *mentity.User) error {
+	funcName := "[UserRepositoryMongo.Create]"
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return apperr.Wrap(err, funcName)
+	}
+
+	ID, err := reserveIDs(ctx, u.db, UserCollection, 1)
+	if err != nil {
+		return apperr.Wrap(err, funcName)
+	}
+
+	document := entity.UserCollection{
+		ID:       ID,
+		Email:    user.Email,
+		Phone:    user.Phone,
+		Password: user.Password,
+		Name:     user.Name,
+		Role:     user.Role,
+	}
+	if _, err := u.collection.InsertOne(ctx, document); err != nil {
+		return apperr.Wrap(err, funcName)
+	}
+
+	user.ID = ID
+	return nil
+}
+
+// LockByID returns the user of ID, MongoDB has no row locks to take
+func (u *User) LockByID(ctx context.Context, dbTrx mysql.TrxObj, ID int64) (*mentity.User, error) {
+	return u.findOne(ctx, "[UserRepositoryMongo.LockByID]", bson.M{"_id": ID})
+}
+
+func (u *User) GetByEmail(ctx context.Context, email string) (*mentity.User, error) {
+	return u.findOne(ctx, "[UserRepositoryMongo.GetByEmail]", bson.M{"email": email})
+}
+
+func (u *User) GetByEmailAndRole(ctx context.Context, email string, role mentity.RoleType) (*mentity.User, error) {
+	return u.findOne(ctx, "[UserRepositoryMongo.GetByEmailAndRole]", bson.M{"email": email, "role": role})
+}
+
+// GetByRole returns a user of role, any of them when there are several
+func (u *User) GetByRole(ctx context.Context, role mentity.RoleType) (*mentity.User, error) {
+	return u.findOne(ctx, "[UserRepositoryMongo.GetByRole]", bson.M{"role": role})
+}
+
+func (u *User) findOne(ctx context.Context, funcName string, filter bson.M) (*mentity.User, error) {
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, apperr.Wrap(err, funcName)
+	}
+
+	var document entity.UserCollection
+	err := u.collection.FindOne(ctx, filter).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, apperr.ErrUserNotFound()
+	}
+	if err != nil {
+		return nil, apperr.Wrap(err, funcName)
+	}
+
+	return &mentity.User{
+		ID:       document.ID,
+		Email:    document.Email,
+		Phone:    document.Phone,
+		Password: document.Password,
+		Name:     document.Name,
+		Role:     document.Role,
+	}, nil
+}
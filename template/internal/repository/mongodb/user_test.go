@@ -0,0 +1,99 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mongodb"
+	mentity "github.com/rahmatrdn/go-skeleton/internal/repository/mysql/entity"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// counterResponse answers the findAndModify of the ID sequence with seq
+func counterResponse(seq int64) bson.D {
+	return mtest.CreateSuccessResponse(bson.E{Key: "value", Value: bson.D{{Key: "_id", Value: "counter"}, {Key: "seq", Value: seq}}})
+}
+
+func TestUserCreate(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("stores the user with the next ID of the sequence", func(mt *mtest.T) {
+		mt.AddMockResponses(counterResponse(7), mtest.CreateSuccessResponse())
+
+		user := &mentity.User{Email: "user@example.com", Name: "User"}
+		err := mongodb.NewUserRepository(mt.DB).Create(context.Background(), nil, user)
+		assert.NoError(mt, err)
+		assert.Equal(mt, int64(7), user.ID)
+
+		counter := mt.GetStartedEvent()
+		assert.Equal(mt, "findAndModify", counter.CommandName)
+		assert.Equal(mt, mongodb.CounterCollection, counter.Command.Lookup("findAndModify").StringValue())
+
+		insert := mt.GetStartedEvent()
+		documents, _ := insert.Command.Lookup("documents").Array().Values()
+		assert.Equal(mt, int64(7), documents[0].Document().Lookup("_id").Int64())
+		assert.Equal(mt, "user@example.com", documents[0].Document().Lookup("email").StringValue())
+	})
+
+	mt.Run("doesn't insert without an ID", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 11600, Message: "interrupted"}))
+
+		err := mongodb.NewUserRepository(mt.DB).Create(context.Background(), nil, &mentity.User{})
+		assert.Error(mt, err)
+		assert.Empty(mt, insertedBatches(mt))
+	})
+}
+
+func TestUserGetByEmail(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	namespace := "test." + mongodb.UserCollection
+
+	mt.Run("returns the user", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, namespace, mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: int64(3)}, {Key: "email", Value: "user@example.com"}, {Key: "role", Value: 2}},
+		))
+
+		user, err := mongodb.NewUserRepository(mt.DB).GetByEmail(context.Background(), "user@example.com")
+		assert.NoError(mt, err)
+		assert.Equal(mt, &mentity.User{ID: 3, Email: "user@example.com", Role: 2}, user)
+	})
+
+	mt.Run("returns ErrUserNotFound without a match", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, namespace, mtest.FirstBatch))
+
+		user, err := mongodb.NewUserRepository(mt.DB).GetByEmail(context.Background(), "missing@example.com")
+		assert.Nil(mt, user)
+		assert.Equal(mt, apperr.ErrUserNotFound(), err)
+	})
+}
+
+func TestUserGetByRole(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	namespace := "test." + mongodb.UserCollection
+
+	mt.Run("returns a user of the role", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, namespace, mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: int64(1)}, {Key: "email", Value: "admin@example.com"}, {Key: "role", Value: 1}},
+		))
+
+		user, err := mongodb.NewUserRepository(mt.DB).GetByRole(context.Background(), mentity.RoleTypeAdmin)
+		assert.NoError(mt, err)
+		assert.Equal(mt, &mentity.User{ID: 1, Email: "admin@example.com", Role: 1}, user)
+	})
+
+	mt.Run("returns ErrUserNotFound without one", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, namespace, mtest.FirstBatch))
+
+		user, err := mongodb.NewUserRepository(mt.DB).GetByRole(context.Background(), mentity.RoleTypeAdmin)
+		assert.Nil(mt, user)
+		assert.Equal(mt, apperr.ErrUserNotFound(), err)
+	})
+}
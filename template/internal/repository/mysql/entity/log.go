@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type Log struct {
+	ID            int64     `gorm:"column:id;primaryKey"`
+	Status        string    `gorm:"column:status"`
+	Message       string    `gorm:"column:message"`
+	FuncName      string    `gorm:"column:func_name"`
+	ErrorMessage  string    `gorm:"column:error_message"`
+	Process       string    `gorm:"column:process_name"`
+	LogFields     string    `gorm:"column:log_fields"`
+	ExecutionTime int       `gorm:"column:exec_time"`
+	Created       time.Time `gorm:"column:created"`
+}
+
+func (Log) TableName() string {
+	return "logs"
+}
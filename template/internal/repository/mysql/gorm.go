@@ -3,7 +3,7 @@ package mysql
 import (
 	"database/sql"
 
-	"github.com/pkg/errors"
+	apperr "github.com/rahmatrdn/go-skeleton/error"
 	"gorm.io/gorm"
 )
 
@@ -60,7 +60,7 @@ func DBTransaction(repo TrxSupportRepo, callback func(TrxObj) error) (err error)
 	commit := false
 	trx, err := repo.Begin()
 	if err != nil {
-		return err
+		return apperr.Wrap(err, functionName)
 	}
 
 	defer func(commit *bool, repo TrxSupportRepo, trx TrxObj) {
@@ -69,7 +69,7 @@ func DBTransaction(repo TrxSupportRepo, callback func(TrxObj) error) (err error)
 				if err == nil {
 					err = rErr
 				} else {
-					err = errors.Wrap(rErr, err.Error())
+					err = apperr.Wrap(rErr, err.Error())
 				}
 			}
 		}
@@ -82,7 +82,7 @@ func DBTransaction(repo TrxSupportRepo, callback func(TrxObj) error) (err error)
 	}
 
 	if err = trx.Commit(); err != nil {
-		return errors.Wrap(err, functionName)
+		return apperr.Wrap(err, functionName)
 	}
 	commit = true
 
@@ -6,7 +6,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/rahmatrdn/go-skeleton/config"
 	"github.com/rahmatrdn/go-skeleton/tests/mocks"
 
 	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql"
@@ -52,7 +51,7 @@ func (s *GormTrxSupportTestSuite) SetupTest() {
 
 	dialector := gmysql.New(gmysql.Config{Conn: s.db, SkipInitializeWithVersion: true})
 	gormDB, _ := gorm.Open(dialector, &gorm.Config{})
-	s.repo = mysql.NewUserRepository(&config.Mysql{DB: gormDB})
+	s.repo = mysql.NewUserRepository(gormDB)
 	s.d = &GormTrxSupportTestData{}
 }
 
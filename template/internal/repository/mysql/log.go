@@ -0,0 +1,34 @@
+package mysql
+
+import (
+	"context"
+
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql/entity"
+	"gorm.io/gorm"
+)
+
+// LogRepository stores logs consumed from the queue in the primary SQL database,
+// it is the SQL alternative of mongodb.LogRepository
+type LogRepository interface {
+	Create(ctx context.Context, params *entity.Log) error
+}
+
+type Log struct {
+	GormTrxSupport
+}
+
+func NewLogRepository(db *gorm.DB) *Log {
+	return &Log{GormTrxSupport{db: db}}
+}
+
+func (r *Log) Create(ctx context.Context, params *entity.Log) error {
+	funcName := "LogRepository.Create"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return apperr.Wrap(err, funcName)
+	}
+
+	return apperr.Wrap(r.db.Create(params).Error, funcName)
+}
@@ -2,14 +2,13 @@ package mysql
 
 import (
 	"context"
+	"errors"
 
-	"github.com/rahmatrdn/go-skeleton/config"
 	"github.com/rahmatrdn/go-skeleton/internal/helper"
 	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql/entity"
 
 	apperr "github.com/rahmatrdn/go-skeleton/error"
 
-	errwrap "github.com/pkg/errors"
 	"gorm.io/gorm"
 )
 
@@ -18,6 +17,7 @@ type ITodoListRepository interface {
 	GetByUserID(ctx context.Context, ID int64) (result []*entity.TodoList, err error)
 	GetByID(ctx context.Context, ID int64) (result *entity.TodoList, err error)
 	Create(ctx context.Context, dbTrx TrxObj, params *entity.TodoList, nonZeroVal bool) error
+	CreateBatch(ctx context.Context, dbTrx TrxObj, items []*entity.TodoList, batchSize int) error
 	LockByID(ctx context.Context, dbTrx TrxObj, ID int64) (result *entity.TodoList, err error)
 	Update(ctx context.Context, dbTrx TrxObj, params *entity.TodoList, changes *entity.TodoList) (err error)
 	DeleteByID(ctx context.Context, dbTrx TrxObj, id int64) error
@@ -27,74 +27,93 @@ type TodoListRepository struct {
 	GormTrxSupport
 }
 
-func NewTodoListRepository(mysql *config.Mysql) *TodoListRepository {
-	return &TodoListRepository{GormTrxSupport{db: mysql.DB}}
+func NewTodoListRepository(db *gorm.DB) *TodoListRepository {
+	return &TodoListRepository{GormTrxSupport{db: db}}
 }
 
 func (r *TodoListRepository) GetByUserID(ctx context.Context, userID int64) (result []*entity.TodoList, err error) {
 	funcName := "TodoListRepository.GetByUserID"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
-		return nil, errwrap.Wrap(err, funcName)
+		return nil, apperr.Wrap(err, funcName)
 	}
 
 	err = r.db.Raw("SELECT * FROM todo_lists WHERE user_id = ?", userID).Scan(&result).Error
-	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, apperr.ErrRecordNotFound()
 	}
 
-	return result, err
+	return result, apperr.Wrap(err, funcName)
 }
 
 func (r *TodoListRepository) GetByID(ctx context.Context, ID int64) (result *entity.TodoList, err error) {
 	funcName := "TodoListRepository.GetByID"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
-		return nil, errwrap.Wrap(err, funcName)
+		return nil, apperr.Wrap(err, funcName)
 	}
 
 	err = r.db.Raw("SELECT * FROM todo_lists WHERE id = ? LIMIT 1", ID).Scan(&result).Error
-	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, apperr.ErrRecordNotFound()
 	}
 
-	return result, err
+	return result, apperr.Wrap(err, funcName)
 }
 
 func (r *TodoListRepository) Create(ctx context.Context, dbTrx TrxObj, params *entity.TodoList, nonZeroVal bool) error {
 	funcName := "TodoListRepository.Create"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
-		return errwrap.Wrap(err, funcName)
+		return apperr.Wrap(err, funcName)
 	}
 
 	cols := helper.NonZeroCols(params, nonZeroVal)
-	return r.Trx(dbTrx).Select(cols).Create(&params).Error
+	return apperr.Wrap(r.Trx(dbTrx).Select(cols).Create(&params).Error, funcName)
+}
+
+// CreateBatch inserts items with one INSERT statement per batchSize rows, all
+// of them in a single statement when batchSize isn't positive
+func (r *TodoListRepository) CreateBatch(ctx context.Context, dbTrx TrxObj, items []*entity.TodoList, batchSize int) error {
+	funcName := "TodoListRepository.CreateBatch"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return apperr.Wrap(err, funcName)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+	if batchSize <= 0 {
+		batchSize = len(items)
+	}
+
+	return apperr.Wrap(r.Trx(dbTrx).CreateInBatches(items, batchSize).Error, funcName)
 }
 
 func (r *TodoListRepository) LockByID(ctx context.Context, dbTrx TrxObj, ID int64) (result *entity.TodoList, err error) {
 	funcName := "TodoListRepository.LockByID"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
-		return nil, errwrap.Wrap(err, funcName)
+		return nil, apperr.Wrap(err, funcName)
 	}
 
 	err = r.Trx(dbTrx).
 		Raw("SELECT * FROM todo_lists WHERE id = ? FOR UPDATE", ID).
 		Scan(&result).Error
 
-	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, apperr.ErrRecordNotFound()
 	}
 
-	return result, err
+	return result, apperr.Wrap(err, funcName)
 }
 
 func (r *TodoListRepository) Update(ctx context.Context, dbTrx TrxObj, params *entity.TodoList, changes *entity.TodoList) (err error) {
 	funcName := "TodoListRepository.Update"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
-		return errwrap.Wrap(err, funcName)
+		return apperr.Wrap(err, funcName)
 	}
 
 	db := r.Trx(dbTrx).Model(params)
@@ -105,7 +124,7 @@ func (r *TodoListRepository) Update(ctx context.Context, dbTrx TrxObj, params *e
 	}
 
 	if err != nil {
-		return errwrap.Wrap(err, funcName)
+		return apperr.Wrap(err, funcName)
 	}
 
 	return nil
@@ -115,12 +134,12 @@ func (r *TodoListRepository) DeleteByID(ctx context.Context, dbTrx TrxObj, id in
 	funcName := "TodoListRepository.DeleteByID"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
-		return errwrap.Wrap(err, funcName)
+		return apperr.Wrap(err, funcName)
 	}
 
 	err := r.Trx(dbTrx).Where("id = ?", id).Delete(&entity.TodoList{}).Error
 	if err != nil {
-		return err
+		return apperr.Wrap(err, funcName)
 	}
 
 	return nil
@@ -0,0 +1,83 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql"
+	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql/entity"
+	"github.com/stretchr/testify/suite"
+	gmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type TodoListRepositoryTestSuite struct {
+	suite.Suite
+	mock sqlmock.Sqlmock
+	db   *sql.DB
+	repo *mysql.TodoListRepository
+}
+
+func TestTodoListRepository(t *testing.T) {
+	suite.Run(t, new(TodoListRepositoryTestSuite))
+}
+
+func (s *TodoListRepositoryTestSuite) SetupTest() {
+	var err error
+	s.db, s.mock, err = sqlmock.New()
+	s.Require().NoError(err)
+
+	dialector := gmysql.New(gmysql.Config{Conn: s.db, SkipInitializeWithVersion: true})
+	gormDB, err := gorm.Open(dialector, &gorm.Config{})
+	s.Require().NoError(err)
+	s.repo = mysql.NewTodoListRepository(gormDB)
+}
+
+func (s *TodoListRepositoryTestSuite) TearDownTest() {
+	s.NoError(s.mock.ExpectationsWereMet())
+	s.db.Close()
+}
+
+func (s *TodoListRepositoryTestSuite) items(count int) []*entity.TodoList {
+	items := []*entity.TodoList{}
+	for i := 0; i < count; i++ {
+		items = append(items, &entity.TodoList{Title: "Imported", UserID: 1})
+	}
+	return items
+}
+
+func (s *TodoListRepositoryTestSuite) TestCreateBatch() {
+	twoRows := "INSERT INTO `todo_lists` .* VALUES \\(.+\\),\\(.+\\)$"
+	oneRow := "INSERT INTO `todo_lists` .* VALUES \\([^)]+\\)$"
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(twoRows).WillReturnResult(sqlmock.NewResult(1, 2))
+	s.mock.ExpectExec(oneRow).WillReturnResult(sqlmock.NewResult(3, 1))
+	s.mock.ExpectCommit()
+
+	s.NoError(s.repo.CreateBatch(context.Background(), nil, s.items(3), 2))
+}
+
+func (s *TodoListRepositoryTestSuite) TestCreateBatchWithoutBatchSize() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("INSERT INTO `todo_lists` .* VALUES \\(.+\\),\\(.+\\),\\(.+\\)$").WillReturnResult(sqlmock.NewResult(1, 3))
+	s.mock.ExpectCommit()
+
+	s.NoError(s.repo.CreateBatch(context.Background(), nil, s.items(3), 0))
+}
+
+func (s *TodoListRepositoryTestSuite) TestCreateBatchRollsBackOnError() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("INSERT INTO `todo_lists`").WillReturnResult(sqlmock.NewResult(1, 2))
+	s.mock.ExpectExec("INSERT INTO `todo_lists`").WillReturnError(errors.New("duplicate entry"))
+	s.mock.ExpectRollback()
+
+	s.Error(s.repo.CreateBatch(context.Background(), nil, s.items(3), 2))
+}
+
+func (s *TodoListRepositoryTestSuite) TestCreateBatchEmpty() {
+	s.NoError(s.repo.CreateBatch(context.Background(), nil, nil, 2))
+}
@@ -2,9 +2,8 @@ package mysql
 
 import (
 	"context"
+	"errors"
 
-	errwrap "github.com/pkg/errors"
-	"github.com/rahmatrdn/go-skeleton/config"
 	apperr "github.com/rahmatrdn/go-skeleton/error"
 	"github.com/rahmatrdn/go-skeleton/internal/helper"
 	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql/entity"
@@ -19,66 +18,83 @@ type UserRepository interface {
 	LockByID(ctx context.Context, dbTrx TrxObj, ID int64) (*entity.User, error)
 	GetByEmail(ctx context.Context, email string) (*entity.User, error)
 	GetByEmailAndRole(ctx context.Context, email string, role entity.RoleType) (*entity.User, error)
+	GetByRole(ctx context.Context, role entity.RoleType) (*entity.User, error)
 }
 
 type User struct {
 	GormTrxSupport
 }
 
-func NewUserRepository(mysql *config.Mysql) *User {
-	return &User{GormTrxSupport{db: mysql.DB}}
+func NewUserRepository(db *gorm.DB) *User {
+	return &User{GormTrxSupport{db: db}}
 }
 
 func (u *User) Create(ctx context.Context, dbTrx TrxObj, user *entity.User) error {
 	funcName := "UserRepository.Create"
 	if err := helper.CheckDeadline(ctx); err != nil {
-		return errwrap.Wrap(err, funcName)
+		return apperr.Wrap(err, funcName)
 	}
 
-	return u.Trx(dbTrx).Create(&user).Error
+	return apperr.Wrap(u.Trx(dbTrx).Create(&user).Error, funcName)
 }
 
 func (u *User) LockByID(ctx context.Context, dbTrx TrxObj, ID int64) (*entity.User, error) {
 	funcName := "UserRepository.GetUserByID"
 	if err := helper.CheckDeadline(ctx); err != nil {
-		return nil, errwrap.Wrap(err, funcName)
+		return nil, apperr.Wrap(err, funcName)
 	}
 
 	var user *entity.User
 	err := u.Trx(dbTrx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ID).Take(&user).Error
-	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, apperr.ErrUserNotFound()
 	}
 
-	return user, err
+	return user, apperr.Wrap(err, funcName)
 }
 
 func (u *User) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
 	funcName := "UserRepository.GetByEmail"
 	if err := helper.CheckDeadline(ctx); err != nil {
-		return nil, errwrap.Wrap(err, funcName)
+		return nil, apperr.Wrap(err, funcName)
 	}
 
 	var user *entity.User
 	err := u.db.Where("email = ?", email).Take(&user).Error
-	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, apperr.ErrUserNotFound()
 	}
 
-	return user, err
+	return user, apperr.Wrap(err, funcName)
 }
 
 func (u *User) GetByEmailAndRole(ctx context.Context, email string, role entity.RoleType) (*entity.User, error) {
 	funcName := "UserRepository.GetByEmailAndRole"
 	if err := helper.CheckDeadline(ctx); err != nil {
-		return nil, errwrap.Wrap(err, funcName)
+		return nil, apperr.Wrap(err, funcName)
 	}
 
 	var user *entity.User
 	err := u.db.Where("email = ? AND role = ?", email, role).Take(&user).Error
-	if errwrap.Is(err, gorm.ErrRecordNotFound) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, apperr.ErrUserNotFound()
 	}
 
-	return user, err
+	return user, apperr.Wrap(err, funcName)
+}
+
+// GetByRole returns a user of role, any of them when there are several
+func (u *User) GetByRole(ctx context.Context, role entity.RoleType) (*entity.User, error) {
+	funcName := "UserRepository.GetByRole"
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, apperr.Wrap(err, funcName)
+	}
+
+	var user *entity.User
+	err := u.db.Where("role = ?", role).Take(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperr.ErrUserNotFound()
+	}
+
+	return user, apperr.Wrap(err, funcName)
 }
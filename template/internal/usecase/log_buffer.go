@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/entity"
+	"go.uber.org/zap"
+)
+
+// logBuffer holds the logs the queue refused until it's back. It keeps the
+// newest size logs, dropping the oldest when full.
+type logBuffer struct {
+	size int
+
+	mu      sync.Mutex
+	logs    []entity.Log
+	dropped int
+}
+
+func (b *logBuffer) push(logs ...entity.Log) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.logs = append(b.logs, logs...)
+	if over := len(b.logs) - b.size; over > 0 {
+		b.logs = b.logs[over:]
+		b.dropped += over
+	}
+}
+
+// flush publishes the buffered logs in order, putting back the ones left when
+// publish fails. It returns the number of logs dropped since the last flush.
+func (b *logBuffer) flush(ctx context.Context, publish func(context.Context, entity.Log) error) (dropped int, err error) {
+	b.mu.Lock()
+	logs, dropped := b.logs, b.dropped
+	b.logs, b.dropped = nil, 0
+	b.mu.Unlock()
+
+	for i, log := range logs {
+		if err := publish(ctx, log); err != nil {
+			// Logs buffered meanwhile are newer than the ones left
+			b.mu.Lock()
+			b.logs = append(logs[i:len(logs):len(logs)], b.logs...)
+			b.dropped += dropped
+			b.mu.Unlock()
+			b.push()
+			return 0, err
+		}
+	}
+
+	return dropped, nil
+}
+
+// RetryFailed keeps up to size logs the queue failed to publish and publishes
+// them again every interval until ctx is done. They are still written with Zap
+// right away, the retry delivers them to the worker once RabbitMQ is back.
+// Call it before the usecase is used.
+func (w *Log) RetryFailed(ctx context.Context, size int, interval time.Duration) {
+	w.buffer = &logBuffer{size: size}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				dropped, err := w.buffer.flush(ctx, w.publish)
+				if err == nil && dropped > 0 {
+					w.zapLogger.Warn("Logs dropped while the queue was down", zap.Int("dropped", dropped))
+				}
+			}
+		}
+	}()
+}
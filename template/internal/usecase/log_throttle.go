@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/entity"
+)
+
+// LogThrottle is how LogUsecase handles duplicate logs, logs with the same
+// status, message, function and error, published within a window
+type LogThrottle string
+
+const (
+	// LogThrottleNone publishes every log
+	LogThrottleNone LogThrottle = "none"
+	// LogThrottleSample publishes the first log of a window and drops its duplicates
+	LogThrottleSample LogThrottle = "sample"
+	// LogThrottleCoalesce publishes the first log of a window, then a single
+	// log for its duplicates when the window ends, counted in the "repeated" field
+	LogThrottleCoalesce LogThrottle = "coalesce"
+)
+
+// RepeatedLogField holds the number of duplicates a coalesced log stands for
+const RepeatedLogField = "repeated"
+
+type logThrottle struct {
+	strategy LogThrottle
+	window   time.Duration
+	// flush publishes the log standing for the duplicates of a window
+	flush func(entity.Log)
+
+	mu      sync.Mutex
+	windows map[string]*logWindow
+}
+
+type logWindow struct {
+	repeats int
+	last    entity.Log
+}
+
+func newLogThrottle(strategy LogThrottle, window time.Duration, flush func(entity.Log)) (*logThrottle, error) {
+	switch strategy {
+	case LogThrottleSample, LogThrottleCoalesce:
+	default:
+		return nil, fmt.Errorf("unknown log throttle %q, use none, sample or coalesce", strategy)
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("log throttle window must be positive, got %s", window)
+	}
+
+	return &logThrottle{strategy: strategy, window: window, flush: flush, windows: map[string]*logWindow{}}, nil
+}
+
+// admit reports whether log has to be published now. The first log opens a
+// window, its duplicates are held back until the window ends.
+func (t *logThrottle) admit(log entity.Log) bool {
+	key := string(log.Status) + "|" + log.Message + "|" + log.FuncName + "|" + log.ErrorMessage
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if window, ok := t.windows[key]; ok {
+		window.repeats++
+		window.last = log
+		return false
+	}
+
+	t.windows[key] = &logWindow{}
+	time.AfterFunc(t.window, func() { t.close(key) })
+	return true
+}
+
+func (t *logThrottle) close(key string) {
+	t.mu.Lock()
+	window := t.windows[key]
+	delete(t.windows, key)
+	t.mu.Unlock()
+
+	if t.strategy != LogThrottleCoalesce || window.repeats == 0 {
+		return
+	}
+
+	fields := entity.CaptureFields{}
+	for name, value := range window.last.LogFields {
+		fields[name] = value
+	}
+	fields[RepeatedLogField] = strconv.Itoa(window.repeats)
+	window.last.LogFields = fields
+
+	t.flush(window.last)
+}
@@ -1,8 +1,10 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"os"
+	"time"
 
 	"github.com/rahmatrdn/go-skeleton/entity"
 	"github.com/rahmatrdn/go-skeleton/internal/helper"
@@ -14,30 +16,56 @@ import (
 type Log struct {
 	queue     queue.Queue
 	zapLogger *zap.Logger
+	throttle  *logThrottle
+	buffer    *logBuffer
 }
 
 func NewLogUsecase(
 	queue queue.Queue,
 	zapLogger *zap.Logger,
 ) *Log {
-	return &Log{queue, zapLogger}
+	return &Log{queue: queue, zapLogger: zapLogger}
+}
+
+// NewThrottledLogUsecase is NewLogUsecase with duplicate logs throttled within
+// window, so an error storm doesn't flood the queue. See LogThrottle for the
+// strategies, LogThrottleNone behaves like NewLogUsecase.
+func NewThrottledLogUsecase(
+	queue queue.Queue,
+	zapLogger *zap.Logger,
+	strategy LogThrottle,
+	window time.Duration,
+) (*Log, error) {
+	w := NewLogUsecase(queue, zapLogger)
+	if strategy == LogThrottleNone || strategy == "" {
+		return w, nil
+	}
+
+	throttle, err := newLogThrottle(strategy, window, w.publishCoalesced)
+	if err != nil {
+		return nil, err
+	}
+	w.throttle = throttle
+
+	return w, nil
 }
 
 type LogUsecase interface {
-	Log(status entity.LogType, message string, funcName string, err error, logFields map[string]string, processName string)
-	Error(process string, funcName string, err error, logFields map[string]string)
-	Info(message string, funcName string, logFields map[string]string, processName string)
+	Log(ctx context.Context, status entity.LogType, message string, funcName string, err error, logFields map[string]string, processName string)
+	Error(ctx context.Context, process string, funcName string, err error, logFields map[string]string)
+	Info(ctx context.Context, message string, funcName string, logFields map[string]string, processName string)
 }
 
 // Process writing log to file.
 // Parameters :
+//   - ctx: context of the request, publishing to the queue stops when it's done
 //   - status: status of log (Check entity.LogType)
 //   - message: message to descirbe the error (You can use it to indicate error dependencies/functions)
 //   - funcName: source function that return error (Ex. TodoListUsecase.Create, etc.)
 //   - err: error response from function
 //   - logFields: additional data to track error (Ex. Indetifier ID, User ID, etc.)
 //   - processName: name of process (optional, this can be use to track bug by process name) and make sure using Type Safety to write process name
-func (w *Log) Log(status entity.LogType, message string, funcName string, err error, logFields map[string]string, processName string) {
+func (w *Log) Log(ctx context.Context, status entity.LogType, message string, funcName string, err error, logFields map[string]string, processName string) {
 	logData := entity.Log{
 		Process:      processName,
 		FuncName:     funcName,
@@ -45,10 +73,16 @@ func (w *Log) Log(status entity.LogType, message string, funcName string, err er
 		ErrorMessage: err.Error(),
 		Status:       status,
 		LogFields:    logFields,
+		RequestID:    helper.RequestID(ctx),
 	}
 
-	payload, _ := helper.Serialize(logData)
-	errQueue := w.queue.Publish(queue.ProcessSyncLog, payload, 1)
+	var errQueue error
+	if w.throttle == nil || w.throttle.admit(logData) {
+		errQueue = w.publish(ctx, logData)
+	}
+	if errQueue != nil && w.buffer != nil {
+		w.buffer.push(logData)
+	}
 
 	// Writing Log with Zap Logger
 	logger := w.zapLogger.WithOptions(zap.AddCallerSkip(1))
@@ -59,6 +93,7 @@ func (w *Log) Log(status entity.LogType, message string, funcName string, err er
 		zap.String("message", message),
 		zap.String("errorMessage", err.Error()),
 		zap.Any("logFields", logFields),
+		zap.String("request_id", logData.RequestID),
 	}
 
 	// If error when publish to queue, write log to file
@@ -73,10 +108,33 @@ func (w *Log) Log(status entity.LogType, message string, funcName string, err er
 	}
 }
 
-func (w *Log) Error(process string, funcName string, err error, logFields map[string]string) {
-	w.Log(entity.LogError, process, funcName, err, logFields, process)
+func (w *Log) publish(ctx context.Context, logData entity.Log) error {
+	payload, _ := helper.Serialize(logData)
+	return w.queue.Publish(ctx, queue.ProcessSyncLog, payload, 1)
+}
+
+// publishCoalesced publishes the log standing for the duplicates of a throttle
+// window, writing it with Zap when the queue fails. The window outlives the
+// requests that logged the duplicates, so it doesn't use their context.
+func (w *Log) publishCoalesced(logData entity.Log) {
+	if err := w.publish(context.Background(), logData); err != nil {
+		if w.buffer != nil {
+			w.buffer.push(logData)
+		}
+		w.zapLogger.Error(logData.Message,
+			zap.String("process", logData.Process),
+			zap.String("funcName", logData.FuncName),
+			zap.String("errorMessage", logData.ErrorMessage),
+			zap.Any("logFields", logData.LogFields),
+			zap.String("request_id", logData.RequestID),
+		)
+	}
+}
+
+func (w *Log) Error(ctx context.Context, process string, funcName string, err error, logFields map[string]string) {
+	w.Log(ctx, entity.LogError, process, funcName, err, logFields, process)
 }
 
-func (w *Log) Info(message string, funcName string, logFields map[string]string, processName string) {
-	w.Log(entity.LogInfo, message, funcName, errors.New(""), logFields, processName)
+func (w *Log) Info(ctx context.Context, message string, funcName string, logFields map[string]string, processName string) {
+	w.Log(ctx, entity.LogInfo, message, funcName, errors.New(""), logFields, processName)
 }
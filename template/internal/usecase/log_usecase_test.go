@@ -1,16 +1,22 @@
 package usecase_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/rahmatrdn/go-skeleton/config"
 	"github.com/rahmatrdn/go-skeleton/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/queue"
 	"github.com/rahmatrdn/go-skeleton/internal/usecase"
 	"github.com/rahmatrdn/go-skeleton/tests/mocks"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 type LogUsecaseTestSuite struct {
@@ -43,13 +49,13 @@ func (s *LogUsecaseTestSuite) TestLog() {
 		{
 			name: "success",
 			mockFunc: func() {
-				s.queue.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				s.queue.On("Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 			},
 		},
 		{
 			name: "error publish queue",
 			mockFunc: func() {
-				s.queue.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("ERROR")).Once()
+				s.queue.On("Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("ERROR")).Once()
 			},
 			wantErr: true,
 		},
@@ -59,7 +65,109 @@ func (s *LogUsecaseTestSuite) TestLog() {
 		s.T().Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 
-			s.usecase.Log(entity.LogError, tt.name, "test.Test", fmt.Errorf("TEST"), captureFieldError, "")
+			s.usecase.Log(context.Background(), entity.LogError, tt.name, "test.Test", fmt.Errorf("TEST"), captureFieldError, "")
 		})
 	}
 }
+
+func (s *LogUsecaseTestSuite) TestPublishesWithContext() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	s.queue.On("Publish", ctx, queue.ProcessSyncLog, mock.Anything, int32(1)).Return(nil).Once()
+
+	s.usecase.Info(ctx, "Checkout", "OrderUsecase.Create", nil, "checkout")
+	s.queue.AssertExpectations(s.T())
+}
+
+// publishedLogs receives the logs published to the queue, including the ones
+// coalesced from a timer
+func (s *LogUsecaseTestSuite) publishedLogs() chan entity.Log {
+	published := make(chan entity.Log, 32)
+	s.queue.On("Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		var log entity.Log
+		s.NoError(json.Unmarshal(args.Get(2).([]byte), &log))
+		published <- log
+	})
+	return published
+}
+
+func (s *LogUsecaseTestSuite) TestCoalescesBurst() {
+	published := s.publishedLogs()
+	logUsecase, err := usecase.NewThrottledLogUsecase(s.queue, s.zapLogger, usecase.LogThrottleCoalesce, 50*time.Millisecond)
+	s.Require().NoError(err)
+
+	for i := 0; i < 10; i++ {
+		logUsecase.Error(context.Background(), "Checkout", "OrderUsecase.Create", fmt.Errorf("connection refused"), map[string]string{"attempt": strconv.Itoa(i)})
+	}
+	logUsecase.Error(context.Background(), "Checkout", "OrderUsecase.Create", fmt.Errorf("timeout"), nil)
+
+	// The first log of each error goes out right away
+	s.Len(published, 2)
+	s.Equal("connection refused", (<-published).ErrorMessage)
+	s.Equal("timeout", (<-published).ErrorMessage)
+
+	select {
+	case coalesced := <-published:
+		s.Equal("connection refused", coalesced.ErrorMessage)
+		s.Equal("9", coalesced.LogFields[usecase.RepeatedLogField])
+		s.Equal("9", coalesced.LogFields["attempt"])
+	case <-time.After(time.Second):
+		s.Fail("the duplicates were not coalesced")
+	}
+
+	// A new window starts once the previous one is closed
+	logUsecase.Error(context.Background(), "Checkout", "OrderUsecase.Create", fmt.Errorf("connection refused"), nil)
+	s.Len(published, 1)
+}
+
+func (s *LogUsecaseTestSuite) TestSamplesBurst() {
+	published := s.publishedLogs()
+	logUsecase, err := usecase.NewThrottledLogUsecase(s.queue, s.zapLogger, usecase.LogThrottleSample, 20*time.Millisecond)
+	s.Require().NoError(err)
+
+	for i := 0; i < 10; i++ {
+		logUsecase.Error(context.Background(), "Checkout", "OrderUsecase.Create", fmt.Errorf("connection refused"), nil)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	s.Len(published, 1)
+}
+
+func (s *LogUsecaseTestSuite) TestUnknownThrottle() {
+	_, err := usecase.NewThrottledLogUsecase(s.queue, s.zapLogger, "drop", time.Second)
+	s.Error(err)
+}
+
+func (s *LogUsecaseTestSuite) TestRetriesFailedLogs() {
+	core, written := observer.New(zap.InfoLevel)
+	logUsecase := usecase.NewLogUsecase(s.queue, zap.New(core))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logUsecase.RetryFailed(ctx, 2, 30*time.Millisecond)
+
+	// RabbitMQ is down for the first logs and the first retry
+	s.queue.On("Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("connection closed")).Times(4)
+	published := s.publishedLogs()
+
+	for _, err := range []string{"first", "second", "third"} {
+		logUsecase.Error(context.Background(), "Checkout", "OrderUsecase.Create", fmt.Errorf("%s", err), nil)
+	}
+
+	// The fallback writes each log right away
+	s.Equal(3, written.FilterMessage("Checkout").Len())
+
+	// The oldest log doesn't fit in the buffer, the others are published in order
+	for _, want := range []string{"second", "third"} {
+		select {
+		case log := <-published:
+			s.Equal(want, log.ErrorMessage)
+		case <-time.After(time.Second):
+			s.FailNow("the buffered logs were not published")
+		}
+	}
+	s.Eventually(func() bool {
+		return written.FilterMessage("Logs dropped while the queue was down").Len() == 1
+	}, time.Second, 10*time.Millisecond)
+}
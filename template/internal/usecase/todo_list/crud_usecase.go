@@ -3,10 +3,9 @@ package todo_list_usecase
 import (
 	"context"
 	"fmt"
-	"time"
 
-	errwrap "github.com/pkg/errors"
 	generalEntity "github.com/rahmatrdn/go-skeleton/entity"
+	apperr "github.com/rahmatrdn/go-skeleton/error"
 	"github.com/rahmatrdn/go-skeleton/internal/helper"
 	"github.com/rahmatrdn/go-skeleton/internal/repository/mysql"
 	mentity "github.com/rahmatrdn/go-skeleton/internal/repository/mysql/entity"
@@ -42,7 +41,7 @@ func (t *CrudTodoListUsecase) GetByUserID(ctx context.Context, userID int64) (re
 	if err != nil {
 		helper.LogError("todoListRepo.GetByUserID", funcName, err, captureFieldError, "")
 
-		return nil, err
+		return nil, apperr.Wrap(err, funcName)
 	}
 
 	for _, v := range result {
@@ -69,7 +68,7 @@ func (t *CrudTodoListUsecase) GetByID(ctx context.Context, todoListID int64) (*e
 	if err != nil {
 		helper.LogError("todoListRepo.GetByID", funcName, err, captureFieldError, "")
 
-		return nil, err
+		return nil, apperr.Wrap(err, funcName)
 	}
 	if data == nil {
 		return nil, nil
@@ -93,7 +92,7 @@ func (t *CrudTodoListUsecase) Create(ctx context.Context, todoListReq entity.Tod
 	}
 
 	if errMsg := usecase.ValidateStruct(todoListReq); errMsg != "" {
-		return nil, errwrap.Wrap(fmt.Errorf(generalEntity.INVALID_PAYLOAD_CODE), errMsg)
+		return nil, apperr.Wrap(fmt.Errorf(generalEntity.INVALID_PAYLOAD_CODE), errMsg)
 	}
 
 	doingAt, _ := helper.ParseDate(todoListReq.DoingAt)
@@ -103,14 +102,14 @@ func (t *CrudTodoListUsecase) Create(ctx context.Context, todoListReq entity.Tod
 		Title:       todoListReq.Title,
 		Description: todoListReq.Description,
 		DoingAt:     doingAt,
-		CreatedAt:   time.Now(),
+		CreatedAt:   helper.Now(),
 	}
 
 	err := t.todoListRepo.Create(ctx, nil, todoListPayload, false)
 	if err != nil {
 		helper.LogError("todoListRepo.Create", funcName, err, captureFieldError, "")
 
-		return nil, err
+		return nil, apperr.Wrap(err, funcName)
 	}
 
 	return &entity.TodoListResponse{
@@ -141,7 +140,7 @@ func (t *CrudTodoListUsecase) UpdateByID(ctx context.Context, todoListReq entity
 			return err
 		}
 		if lockedData == nil {
-			return fmt.Errorf("DATA IS NOT EXIST")
+			return apperr.ErrRecordNotFound()
 		}
 
 		// Process Update
@@ -150,7 +149,7 @@ func (t *CrudTodoListUsecase) UpdateByID(ctx context.Context, todoListReq entity
 			Title:       todoListReq.Title,
 			Description: todoListReq.Description,
 			DoingAt:     doingAt,
-			UpdatedAt:   time.Now(),
+			UpdatedAt:   helper.Now(),
 		}); err != nil {
 			helper.LogError("todoListRepo.Update", funcName, err, captureFieldError, "")
 
@@ -161,7 +160,7 @@ func (t *CrudTodoListUsecase) UpdateByID(ctx context.Context, todoListReq entity
 	}); err != nil {
 		helper.LogError("todoListRepo.DBTransaction", funcName, err, captureFieldError, "")
 
-		return err
+		return apperr.Wrap(err, funcName)
 	}
 
 	return nil
@@ -177,7 +176,7 @@ func (t *CrudTodoListUsecase) DeleteByID(ctx context.Context, todoListID int64)
 	if err != nil {
 		helper.LogError("todoListRepo.DeleteByID", funcName, err, captureFieldError, "")
 
-		return err
+		return apperr.Wrap(err, funcName)
 	}
 
 	return nil
@@ -2,9 +2,9 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	errwrap "github.com/pkg/errors"
 	"github.com/rahmatrdn/go-skeleton/entity"
 	apperr "github.com/rahmatrdn/go-skeleton/error"
 	"github.com/rahmatrdn/go-skeleton/internal/helper"
@@ -29,8 +29,12 @@ func NewUserUsecase(
 type UserUsecase interface {
 	VerifyByEmailAndPassword(ctx context.Context, req *entity.LoginReq) (loginRes *entity.LoginResponse, err error)
 	CreateAsGuest(ctx context.Context, createUserReq *entity.CreateUserReq) (*entity.CreateUserResponse, error)
+	BootstrapAdmin(ctx context.Context, req *entity.BootstrapAdminReq) (*entity.LoginResponse, error)
 }
 
+// adminPasswordMinLength is the shortest password BootstrapAdmin accepts
+const adminPasswordMinLength = 8
+
 func (w *User) VerifyByEmailAndPassword(ctx context.Context, req *entity.LoginReq) (loginRes *entity.LoginResponse, err error) {
 	funcName := "UserUsecase.VerifyByEmailAndPassword"
 	captureFieldError := map[string]string{"email": fmt.Sprint(req.Email)}
@@ -39,11 +43,11 @@ func (w *User) VerifyByEmailAndPassword(ctx context.Context, req *entity.LoginRe
 	if err != nil {
 		helper.Log(entity.LogError, "userRepo.GetByEmail", funcName, err, captureFieldError, "")
 
-		if err == apperr.ErrUserNotFound() {
+		if errors.Is(err, apperr.ErrUserNotFound()) {
 			return nil, apperr.ErrInvalidEmailOrPassword()
 		}
 
-		return nil, err
+		return nil, apperr.Wrap(err, funcName)
 	}
 
 	if !helper.VerifyBcryptHash(req.Password, user.Password) {
@@ -54,7 +58,7 @@ func (w *User) VerifyByEmailAndPassword(ctx context.Context, req *entity.LoginRe
 	if err != nil {
 		helper.Log(entity.LogError, "userRepo.GenerateToken", funcName, err, captureFieldError, "")
 
-		return nil, err
+		return nil, apperr.Wrap(err, funcName)
 	}
 
 	loginRes = &entity.LoginResponse{
@@ -75,14 +79,14 @@ func (w *User) CreateAsGuest(ctx context.Context, createUserReq *entity.CreateUs
 	}
 
 	if errMsg := ValidateStruct(*createUserReq); errMsg != "" {
-		return nil, errwrap.Wrap(fmt.Errorf(entity.INVALID_PAYLOAD_CODE), errMsg)
+		return nil, apperr.Wrap(fmt.Errorf(entity.INVALID_PAYLOAD_CODE), errMsg)
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(createUserReq.Password), bcrypt.DefaultCost)
 	if err != nil {
 		helper.LogError("bcrypt.GenerateFromPassword", funcName, err, captureFieldError, "")
 
-		return nil, err
+		return nil, apperr.Wrap(err, funcName)
 	}
 
 	user := &mentity.User{
@@ -97,14 +101,14 @@ func (w *User) CreateAsGuest(ctx context.Context, createUserReq *entity.CreateUs
 	if err != nil {
 		helper.LogError("userRepo.Create", funcName, err, captureFieldError, "")
 
-		return nil, err
+		return nil, apperr.Wrap(err, funcName)
 	}
 
 	token, err := w.jwtAuth.GenerateToken(user)
 	if err != nil {
 		helper.LogError("userRepo.GetByEmail", funcName, err, captureFieldError, "")
 
-		return nil, err
+		return nil, apperr.Wrap(err, funcName)
 	}
 
 	return &entity.CreateUserResponse{
@@ -116,3 +120,76 @@ func (w *User) CreateAsGuest(ctx context.Context, createUserReq *entity.CreateUs
 		Token:      token,
 	}, nil
 }
+
+// BootstrapAdmin creates the first admin of a fresh deploy with a hashed
+// password and returns it with a token. When an admin exists it does nothing
+// and returns nil, so it can run on every deploy.
+func (w *User) BootstrapAdmin(ctx context.Context, req *entity.BootstrapAdminReq) (*entity.LoginResponse, error) {
+	funcName := "UserUsecase.BootstrapAdmin"
+	captureFieldError := entity.CaptureFields{
+		"email": req.Email,
+	}
+
+	if req.Email == "" {
+		return nil, apperr.Validation("Email is required")
+	}
+	if len(req.Password) < adminPasswordMinLength {
+		return nil, apperr.Validation(fmt.Sprintf("Password must be at least %d characters", adminPasswordMinLength))
+	}
+
+	_, err := w.userRepo.GetByRole(ctx, mentity.RoleTypeAdmin)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, apperr.ErrUserNotFound()) {
+		helper.LogError("userRepo.GetByRole", funcName, err, captureFieldError, "")
+
+		return nil, apperr.Wrap(err, funcName)
+	}
+
+	_, err = w.userRepo.GetByEmail(ctx, req.Email)
+	if err == nil {
+		return nil, apperr.Validation("A user with the email already exists")
+	}
+	if !errors.Is(err, apperr.ErrUserNotFound()) {
+		helper.LogError("userRepo.GetByEmail", funcName, err, captureFieldError, "")
+
+		return nil, apperr.Wrap(err, funcName)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		helper.LogError("bcrypt.GenerateFromPassword", funcName, err, captureFieldError, "")
+
+		return nil, apperr.Wrap(err, funcName)
+	}
+
+	user := &mentity.User{
+		Name:     req.Name,
+		Email:    req.Email,
+		Role:     int8(entity.Admin),
+		Password: string(hashedPassword),
+	}
+
+	err = w.userRepo.Create(ctx, nil, user)
+	if err != nil {
+		helper.LogError("userRepo.Create", funcName, err, captureFieldError, "")
+
+		return nil, apperr.Wrap(err, funcName)
+	}
+
+	token, err := w.jwtAuth.GenerateToken(user)
+	if err != nil {
+		helper.LogError("jwtAuth.GenerateToken", funcName, err, captureFieldError, "")
+
+		return nil, apperr.Wrap(err, funcName)
+	}
+
+	return &entity.LoginResponse{
+		UserID:     user.ID,
+		Name:       user.Name,
+		Email:      user.Email,
+		RoleAccess: user.Role,
+		Token:      token,
+	}, nil
+}
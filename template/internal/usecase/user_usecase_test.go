@@ -0,0 +1,140 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rahmatrdn/go-skeleton/entity"
+	apperr "github.com/rahmatrdn/go-skeleton/error"
+	"github.com/rahmatrdn/go-skeleton/internal/helper"
+	mentity "github.com/rahmatrdn/go-skeleton/internal/repository/mysql/entity"
+	"github.com/rahmatrdn/go-skeleton/internal/usecase"
+	"github.com/rahmatrdn/go-skeleton/tests/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeJWTAuth struct{}
+
+func (fakeJWTAuth) GenerateToken(user *mentity.User) (string, error) {
+	return fmt.Sprintf("token-of-%d", user.ID), nil
+}
+
+type UserUsecaseTestSuite struct {
+	suite.Suite
+
+	usecase  *usecase.User
+	userRepo *mocks.UserRepository
+}
+
+func (s *UserUsecaseTestSuite) SetupTest() {
+	s.userRepo = &mocks.UserRepository{}
+	s.usecase = usecase.NewUserUsecase(s.userRepo, fakeJWTAuth{})
+}
+
+func TestUserUsecase(t *testing.T) {
+	suite.Run(t, new(UserUsecaseTestSuite))
+}
+
+// usersTable backs GetByRole and GetByEmail with users, Create stores into it
+func (s *UserUsecaseTestSuite) usersTable(users map[string]*mentity.User) {
+	adminOf := func() *mentity.User {
+		for _, user := range users {
+			if mentity.RoleType(user.Role) == mentity.RoleTypeAdmin {
+				return user
+			}
+		}
+		return nil
+	}
+	s.userRepo.On("GetByRole", mock.Anything, mentity.RoleTypeAdmin).Return(
+		func(context.Context, mentity.RoleType) *mentity.User { return adminOf() },
+		func(context.Context, mentity.RoleType) error {
+			if adminOf() == nil {
+				return apperr.ErrUserNotFound()
+			}
+			return nil
+		},
+	)
+	s.userRepo.On("GetByEmail", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, email string) *mentity.User { return users[email] },
+		func(_ context.Context, email string) error {
+			if users[email] == nil {
+				return apperr.ErrUserNotFound()
+			}
+			return nil
+		},
+	)
+	s.userRepo.On("Create", mock.Anything, nil, mock.Anything).Run(func(args mock.Arguments) {
+		user := args.Get(2).(*mentity.User)
+		user.ID = int64(len(users) + 1)
+		users[user.Email] = user
+	}).Return(nil)
+}
+
+func (s *UserUsecaseTestSuite) TestBootstrapAdminTwice() {
+	users := map[string]*mentity.User{}
+	s.usersTable(users)
+
+	req := &entity.BootstrapAdminReq{Name: "Admin", Email: "admin@example.com", Password: "correct horse"}
+
+	admin, err := s.usecase.BootstrapAdmin(context.Background(), req)
+	s.Require().NoError(err)
+	s.Equal(&entity.LoginResponse{UserID: 1, Name: "Admin", Email: "admin@example.com", RoleAccess: int8(entity.Admin), Token: "token-of-1"}, admin)
+	s.True(helper.VerifyBcryptHash("correct horse", users["admin@example.com"].Password), "the password is stored hashed")
+
+	admin, err = s.usecase.BootstrapAdmin(context.Background(), req)
+	s.Require().NoError(err)
+	s.Nil(admin)
+
+	s.userRepo.AssertNumberOfCalls(s.T(), "Create", 1)
+	s.Len(users, 1)
+}
+
+func (s *UserUsecaseTestSuite) TestBootstrapAdminWithAnotherAdmin() {
+	users := map[string]*mentity.User{
+		"first@example.com": {ID: 1, Email: "first@example.com", Role: int8(mentity.RoleTypeAdmin)},
+	}
+	s.usersTable(users)
+
+	admin, err := s.usecase.BootstrapAdmin(context.Background(), &entity.BootstrapAdminReq{Email: "admin@example.com", Password: "correct horse"})
+	s.Require().NoError(err)
+	s.Nil(admin, "an admin of another email exists already")
+	s.userRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *UserUsecaseTestSuite) TestBootstrapAdminEmailOfUser() {
+	users := map[string]*mentity.User{
+		"admin@example.com": {ID: 1, Email: "admin@example.com", Role: int8(mentity.RoleTypeUser)},
+	}
+	s.usersTable(users)
+
+	_, err := s.usecase.BootstrapAdmin(context.Background(), &entity.BootstrapAdminReq{Email: "admin@example.com", Password: "correct horse"})
+
+	var appErr apperr.CustomErrorResponse
+	s.Require().ErrorAs(err, &appErr)
+	s.Equal(entity.ERROR_CODE_VALIDATION_FAILED, appErr.ErrorCode)
+	s.userRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *UserUsecaseTestSuite) TestBootstrapAdminInvalid() {
+	for _, req := range []*entity.BootstrapAdminReq{
+		{Name: "Admin", Password: "correct horse"},
+		{Name: "Admin", Email: "admin@example.com", Password: "short"},
+	} {
+		_, err := s.usecase.BootstrapAdmin(context.Background(), req)
+
+		var appErr apperr.CustomErrorResponse
+		s.Require().ErrorAs(err, &appErr)
+		s.Equal(entity.ERROR_CODE_VALIDATION_FAILED, appErr.ErrorCode)
+	}
+	s.userRepo.AssertNotCalled(s.T(), "GetByRole", mock.Anything, mock.Anything)
+}
+
+func (s *UserUsecaseTestSuite) TestBootstrapAdminRepositoryError() {
+	s.userRepo.On("GetByRole", mock.Anything, mentity.RoleTypeAdmin).Return(nil, fmt.Errorf("connection refused")).Once()
+
+	_, err := s.usecase.BootstrapAdmin(context.Background(), &entity.BootstrapAdminReq{Email: "admin@example.com", Password: "correct horse"})
+	s.ErrorContains(err, "connection refused")
+	s.userRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,83 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var ErrPoolClosed = errors.New("worker pool is closed")
+
+// Job is a unit of work run by the pool, ctx is cancelled when the pool's context is
+type Job func(ctx context.Context)
+
+// Pool runs submitted jobs on a bounded number of goroutines
+type Pool struct {
+	ctx       context.Context
+	jobs      chan Job
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+	done      chan struct{} // closed by Close, stops the idle workers and the pending Submits
+}
+
+// New starts a pool of size workers, a size below 1 runs jobs one at a time
+func New(ctx context.Context, size int) *Pool {
+	if size < 1 {
+		size = 1
+	}
+
+	p := &Pool{
+		ctx:  ctx,
+		jobs: make(chan Job),
+		done: make(chan struct{}),
+	}
+
+	p.wg.Add(size)
+	for i := 0; i < size; i++ {
+		go p.work()
+	}
+
+	return p
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case job := <-p.jobs:
+			job(p.ctx)
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// Submit hands job to the next free worker, blocking while all of them are busy.
+// It fails once the pool is closed or its context is cancelled, a job submitting
+// to its own pool gets ErrPoolClosed instead of blocking a pending Close.
+func (p *Pool) Submit(job Job) error {
+	select {
+	case <-p.done:
+		return ErrPoolClosed
+	default:
+	}
+
+	select {
+	case p.jobs <- job:
+		return nil
+	case <-p.done:
+		return ErrPoolClosed
+	case <-p.ctx.Done():
+		return p.ctx.Err()
+	}
+}
+
+// Close stops accepting jobs and waits for the running ones to finish
+func (p *Pool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+
+	p.wg.Wait()
+}
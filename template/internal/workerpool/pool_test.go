@@ -0,0 +1,103 @@
+package workerpool_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rahmatrdn/go-skeleton/internal/workerpool"
+	"github.com/stretchr/testify/suite"
+)
+
+type PoolTestSuite struct {
+	suite.Suite
+}
+
+func TestPool(t *testing.T) {
+	suite.Run(t, new(PoolTestSuite))
+}
+
+func (s *PoolTestSuite) TestBoundedConcurrency() {
+	pool := workerpool.New(context.Background(), 3)
+
+	var running, maxRunning int32
+	for i := 0; i < 20; i++ {
+		err := pool.Submit(func(ctx context.Context) {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		})
+		s.NoError(err)
+	}
+	pool.Close()
+
+	s.LessOrEqual(maxRunning, int32(3))
+	s.Equal(int32(3), maxRunning)
+}
+
+func (s *PoolTestSuite) TestCloseDrainsRunningJobs() {
+	pool := workerpool.New(context.Background(), 2)
+
+	var done int32
+	for i := 0; i < 6; i++ {
+		s.NoError(pool.Submit(func(ctx context.Context) {
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&done, 1)
+		}))
+	}
+	pool.Close()
+
+	s.Equal(int32(6), atomic.LoadInt32(&done))
+	s.ErrorIs(pool.Submit(func(ctx context.Context) {}), workerpool.ErrPoolClosed)
+}
+
+func (s *PoolTestSuite) TestContextCancellation() {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := workerpool.New(ctx, 1)
+
+	started := make(chan struct{})
+	s.NoError(pool.Submit(func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+	}))
+	<-started
+
+	cancel()
+
+	// The only worker is busy, so Submit has to give up on the cancelled context
+	s.ErrorIs(pool.Submit(func(ctx context.Context) {}), context.Canceled)
+	pool.Close()
+}
+
+func (s *PoolTestSuite) TestSubmitFromAJobDoesntBlockClose() {
+	pool := workerpool.New(context.Background(), 1)
+
+	started := make(chan struct{})
+	submitted := make(chan error, 1)
+	s.NoError(pool.Submit(func(ctx context.Context) {
+		close(started)
+		// The only worker runs this job, so the Submit waits until Close
+		submitted <- pool.Submit(func(ctx context.Context) {})
+	}))
+	<-started
+
+	closed := make(chan struct{})
+	go func() {
+		pool.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		s.FailNow("Close is blocked by the job submitting to the pool")
+	}
+	s.ErrorIs(<-submitted, workerpool.ErrPoolClosed)
+}
@@ -64,6 +64,24 @@ func (_m *ITodoListRepository) Create(ctx context.Context, dbTrx mysql.TrxObj, p
 	return r0
 }
 
+// CreateBatch provides a mock function with given fields: ctx, dbTrx, items, batchSize
+func (_m *ITodoListRepository) CreateBatch(ctx context.Context, dbTrx mysql.TrxObj, items []*entity.TodoList, batchSize int) error {
+	ret := _m.Called(ctx, dbTrx, items, batchSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, mysql.TrxObj, []*entity.TodoList, int) error); ok {
+		r0 = rf(ctx, dbTrx, items, batchSize)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteByID provides a mock function with given fields: ctx, dbTrx, id
 func (_m *ITodoListRepository) DeleteByID(ctx context.Context, dbTrx mysql.TrxObj, id int64) error {
 	ret := _m.Called(ctx, dbTrx, id)
@@ -28,6 +28,20 @@ func (_m *LogRepository) Create(ctx context.Context, params entity.LogCollection
 	return r0
 }
 
+// CreateBatch provides a mock function with given fields: ctx, items, batchSize
+func (_m *LogRepository) CreateBatch(ctx context.Context, items []entity.LogCollection, batchSize int) error {
+	ret := _m.Called(ctx, items, batchSize)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.LogCollection, int) error); ok {
+		r0 = rf(ctx, items, batchSize)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewLogRepository interface {
 	mock.TestingT
 	Cleanup(func())
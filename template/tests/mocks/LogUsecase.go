@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 	entity "github.com/rahmatrdn/go-skeleton/entity"
 )
@@ -12,9 +14,19 @@ type LogUsecase struct {
 	mock.Mock
 }
 
-// Log provides a mock function with given fields: status, message, funcName, err, logFields, processName
-func (_m *LogUsecase) Log(status entity.LogType, message string, funcName string, err error, logFields map[string]string, processName string) {
-	_m.Called(status, message, funcName, err, logFields, processName)
+// Error provides a mock function with given fields: ctx, process, funcName, err, logFields
+func (_m *LogUsecase) Error(ctx context.Context, process string, funcName string, err error, logFields map[string]string) {
+	_m.Called(ctx, process, funcName, err, logFields)
+}
+
+// Info provides a mock function with given fields: ctx, message, funcName, logFields, processName
+func (_m *LogUsecase) Info(ctx context.Context, message string, funcName string, logFields map[string]string, processName string) {
+	_m.Called(ctx, message, funcName, logFields, processName)
+}
+
+// Log provides a mock function with given fields: ctx, status, message, funcName, err, logFields, processName
+func (_m *LogUsecase) Log(ctx context.Context, status entity.LogType, message string, funcName string, err error, logFields map[string]string, processName string) {
+	_m.Called(ctx, status, message, funcName, err, logFields, processName)
 }
 
 type mockConstructorTestingTNewLogUsecase interface {
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	amqp091 "github.com/rabbitmq/amqp091-go"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -64,18 +66,23 @@ func (_m *Queue) Connect() error {
 	return r0
 }
 
+// HandleBroadcastDeliveries provides a mock function with given fields: key, handle
+func (_m *Queue) HandleBroadcastDeliveries(key string, handle func(map[string]interface{}) error) {
+	_m.Called(key, handle)
+}
+
 // HandleConsumedDeliveries provides a mock function with given fields: key, handle
 func (_m *Queue) HandleConsumedDeliveries(key string, handle func(map[string]interface{}) error) {
 	_m.Called(key, handle)
 }
 
-// Publish provides a mock function with given fields: key, message, attempts
-func (_m *Queue) Publish(key string, message []byte, attempts int32) error {
-	ret := _m.Called(key, message, attempts)
+// Publish provides a mock function with given fields: ctx, key, message, attempts
+func (_m *Queue) Publish(ctx context.Context, key string, message []byte, attempts int32) error {
+	ret := _m.Called(ctx, key, message, attempts)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, []byte, int32) error); ok {
-		r0 = rf(key, message, attempts)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, int32) error); ok {
+		r0 = rf(ctx, key, message, attempts)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -99,6 +99,29 @@ func (_m *UserRepository) GetByEmailAndRole(ctx context.Context, email string, r
 	return r0, r1
 }
 
+// GetByRole provides a mock function with given fields: ctx, role
+func (_m *UserRepository) GetByRole(ctx context.Context, role entity.RoleType) (*entity.User, error) {
+	ret := _m.Called(ctx, role)
+
+	var r0 *entity.User
+	if rf, ok := ret.Get(0).(func(context.Context, entity.RoleType) *entity.User); ok {
+		r0 = rf(ctx, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.RoleType) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LockByID provides a mock function with given fields: ctx, dbTrx, ID
 func (_m *UserRepository) LockByID(ctx context.Context, dbTrx mysql.TrxObj, ID int64) (*entity.User, error) {
 	ret := _m.Called(ctx, dbTrx, ID)
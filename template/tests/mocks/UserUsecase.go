@@ -14,6 +14,29 @@ type UserUsecase struct {
 	mock.Mock
 }
 
+// BootstrapAdmin provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) BootstrapAdmin(ctx context.Context, req *entity.BootstrapAdminReq) (*entity.LoginResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *entity.LoginResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.BootstrapAdminReq) *entity.LoginResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.LoginResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.BootstrapAdminReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateAsGuest provides a mock function with given fields: ctx, createUserReq
 func (_m *UserUsecase) CreateAsGuest(ctx context.Context, createUserReq *entity.CreateUserReq) (*entity.CreateUserResponse, error) {
 	ret := _m.Called(ctx, createUserReq)
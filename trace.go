@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// generationTrace times the steps of a generation for --trace-generation,
+// each step lasts until the next one starts
+type generationTrace struct {
+	steps []tracedStep
+}
+
+type tracedStep struct {
+	name  string
+	start time.Time
+}
+
+type generationTraceKey struct{}
+
+// withGenerationTrace makes generationStep record the steps into trace
+func withGenerationTrace(ctx context.Context, trace *generationTrace) context.Context {
+	return context.WithValue(ctx, generationTraceKey{}, trace)
+}
+
+// traceFrom returns the trace of ctx, nil when the generation isn't traced
+func traceFrom(ctx context.Context) *generationTrace {
+	trace, _ := ctx.Value(generationTraceKey{}).(*generationTrace)
+	return trace
+}
+
+// mark starts step, ending the previous one. It does nothing on a nil trace.
+func (t *generationTrace) mark(step string) {
+	if t == nil {
+		return
+	}
+	t.steps = append(t.steps, tracedStep{name: strings.TrimSuffix(step, "..."), start: time.Now()})
+}
+
+// report writes how long each step took until end and its share of the total
+func (t *generationTrace) report(w io.Writer, end time.Time) {
+	if len(t.steps) == 0 {
+		return
+	}
+
+	total := end.Sub(t.steps[0].start)
+	fmt.Fprintln(w, "Generation trace:")
+	for i, step := range t.steps {
+		stepEnd := end
+		if i+1 < len(t.steps) {
+			stepEnd = t.steps[i+1].start
+		}
+		took := stepEnd.Sub(step.start)
+
+		share := 100.0
+		if total > 0 {
+			share = float64(took) / float64(total) * 100
+		}
+		fmt.Fprintf(w, "  %-42s %10s %5.1f%%\n", step.name, took.Round(time.Microsecond), share)
+	}
+	fmt.Fprintf(w, "  %-42s %10s\n", "Total", total.Round(time.Microsecond))
+}
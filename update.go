@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// conflictSuffix is added to the template's version of a file both sides changed
+const conflictSuffix = ".skeleton-new"
+
+// UpdateReport lists what --update-existing did to each file
+type UpdateReport struct {
+	Added     []string // new template files copied into the project
+	Updated   []string // template changes applied to files the user never touched
+	Conflicts []string // changed by both the user and the template, left for manual merge
+	Skipped   []string // deleted by the user, not restored
+	Declined  []string // changes not approved in the review, offered again by the next update
+}
+
+// Kinds of UpdateChange
+const (
+	ChangeAdded    = "added"
+	ChangeUpdated  = "updated"
+	ChangeConflict = "conflict"
+)
+
+// UpdateChange is a template change found by --update-existing, reviewed
+// before anything is written to the project
+type UpdateChange struct {
+	File string
+	Kind string // ChangeAdded, ChangeUpdated or ChangeConflict
+	Diff string // unified diff from the project's file to the template's, empty for added files
+
+	freshPath string
+	freshHash string
+}
+
+// UpdateReviewer returns the changes to apply out of the ones found
+type UpdateReviewer func(changes []UpdateChange) []UpdateChange
+
+// updateExistingProject re-applies the current template to a generated project.
+// The hashes in its manifest tell which files still have their generated
+// content: only those are overwritten, files changed on both sides are
+// reported as conflicts with the new version written next to them. The changes
+// found go through review first, nil applies all of them.
+func updateExistingProject(projectPath string, review UpdateReviewer) (*UpdateReport, error) {
+	entry, err := loadManifest(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("the project's %s is required to update it: %w", ManifestFile, err)
+	}
+
+	// Generate the current template with the recorded options
+	tempDir, err := os.MkdirTemp("", "go-skeleton-update-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	config := *entry.Config
+	config.ProjectPath = filepath.Join(tempDir, config.ProjectName)
+	config.InitGit = false
+	// The recorded module path is kept, the temp copy has no repository's go.work to join
+	config.Monorepo = false
+	if err := createProject(&config); err != nil {
+		return nil, err
+	}
+
+	freshFiles, err := projectFiles(config.ProjectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UpdateReport{}
+	changes := []UpdateChange{}
+	for _, file := range freshFiles {
+		freshPath := filepath.Join(config.ProjectPath, file)
+		targetPath := filepath.Join(projectPath, file)
+
+		freshHash, err := hashFile(freshPath)
+		if err != nil {
+			return nil, err
+		}
+		originalHash, generated := entry.Files[file]
+
+		currentHash, err := hashFile(targetPath)
+		switch {
+		case os.IsNotExist(err) && generated:
+			report.Skipped = append(report.Skipped, file)
+			continue
+		case os.IsNotExist(err):
+			changes = append(changes, UpdateChange{File: file, Kind: ChangeAdded, freshPath: freshPath, freshHash: freshHash})
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		switch {
+		case currentHash == freshHash:
+			entry.Files[file] = freshHash
+		case freshHash == originalHash:
+			// Only the user changed it
+		default:
+			kind := ChangeConflict
+			if currentHash == originalHash {
+				kind = ChangeUpdated
+			}
+			diff, err := fileDiff(file, targetPath, freshPath)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, UpdateChange{File: file, Kind: kind, Diff: diff, freshPath: freshPath, freshHash: freshHash})
+		}
+	}
+
+	approved := changes
+	if review != nil && len(changes) > 0 {
+		approved = review(changes)
+	}
+	isApproved := map[string]bool{}
+	for _, change := range approved {
+		isApproved[change.File] = true
+	}
+
+	for _, change := range changes {
+		if !isApproved[change.File] {
+			report.Declined = append(report.Declined, change.File)
+			continue
+		}
+
+		targetPath := filepath.Join(projectPath, change.File)
+		switch change.Kind {
+		case ChangeAdded:
+			if err := copyProjectFile(change.freshPath, targetPath); err != nil {
+				return nil, err
+			}
+			report.Added = append(report.Added, change.File)
+		case ChangeUpdated:
+			if err := copyProjectFile(change.freshPath, targetPath); err != nil {
+				return nil, err
+			}
+			report.Updated = append(report.Updated, change.File)
+		case ChangeConflict:
+			if err := copyProjectFile(change.freshPath, targetPath+conflictSuffix); err != nil {
+				return nil, err
+			}
+			report.Conflicts = append(report.Conflicts, change.File)
+		}
+		// Once merged by hand a conflicting file is the user's own, until the template changes again
+		entry.Files[change.File] = change.freshHash
+	}
+
+	entry.GeneratedAt = time.Now().UTC()
+	entry.ToolVersion = toolVersion()
+	if err := saveManifest(projectPath, entry); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// fileDiff compares the project's file with the template's version of it
+func fileDiff(file, currentPath, freshPath string) (string, error) {
+	current, err := os.ReadFile(currentPath)
+	if err != nil {
+		return "", err
+	}
+	fresh, err := os.ReadFile(freshPath)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.ContainsRune(string(current), 0) || strings.ContainsRune(string(fresh), 0) {
+		return "Binary file " + file + " differs\n", nil
+	}
+	return unifiedDiff("a/"+file, "b/"+file, string(current), string(fresh)), nil
+}
+
+// copyProjectFile copies a file between two directories on disk
+func copyProjectFile(src, dst string) error {
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, content, 0644)
+}
+
+// reviewUpdateChanges shows the changes with their diff and asks which to
+// apply: all of them, none, or each file in turn
+func reviewUpdateChanges(reader *bufio.Reader) UpdateReviewer {
+	return func(changes []UpdateChange) []UpdateChange {
+		printUpdateChanges(changes)
+
+		fmt.Print(ColorYellow + fmt.Sprintf("⚠ Apply the %d changes? [A]ll, [e]ach file, [n]one: ", len(changes)) + ColorReset)
+		answer, _ := reader.ReadString('\n')
+		switch strings.TrimSpace(strings.ToLower(answer)) {
+		case "", "a", "all":
+			return changes
+		case "e", "each":
+		default:
+			return nil
+		}
+
+		approved := []UpdateChange{}
+		for _, change := range changes {
+			fmt.Print(ColorYellow + "⚠ Apply " + change.File + "? (Y/n): " + ColorReset)
+			answer, _ := reader.ReadString('\n')
+			if answer := strings.TrimSpace(strings.ToLower(answer)); answer == "" || answer == "y" || answer == "yes" {
+				approved = append(approved, change)
+			}
+		}
+		return approved
+	}
+}
+
+func printUpdateChanges(changes []UpdateChange) {
+	fmt.Println()
+	fmt.Println(ColorBlue + "📋 Template changes:" + ColorReset)
+
+	for _, change := range changes {
+		switch change.Kind {
+		case ChangeAdded:
+			fmt.Println(ColorGreen + "  + " + ColorReset + change.File + " (new file)")
+		case ChangeUpdated:
+			fmt.Println(ColorGreen + "  ~ " + ColorReset + change.File)
+		case ChangeConflict:
+			fmt.Println(ColorYellow + "  ! " + change.File + ColorReset + " (also changed in the project, the template's version goes to " + change.File + conflictSuffix + ")")
+		}
+		printDiff(change.Diff)
+	}
+	fmt.Println()
+}
+
+// printDiff indents a unified diff under its file and colors its lines
+func printDiff(diff string) {
+	for _, line := range splitLines(diff) {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			fmt.Println("      " + line)
+		case strings.HasPrefix(line, "@@"):
+			fmt.Println(ColorCyan + "      " + line + ColorReset)
+		case strings.HasPrefix(line, "+"):
+			fmt.Println(ColorGreen + "      " + line + ColorReset)
+		case strings.HasPrefix(line, "-"):
+			fmt.Println(ColorYellow + "      " + line + ColorReset)
+		default:
+			fmt.Println("      " + line)
+		}
+	}
+}
+
+func printUpdateReport(report *UpdateReport) {
+	fmt.Println()
+	fmt.Println(ColorBlue + "📋 Update results:" + ColorReset)
+
+	for _, file := range report.Added {
+		fmt.Println(ColorGreen + "  + " + ColorReset + file)
+	}
+	for _, file := range report.Updated {
+		fmt.Println(ColorGreen + "  ~ " + ColorReset + file)
+	}
+	for _, file := range report.Skipped {
+		fmt.Println("  - " + file + " (deleted in the project, not restored)")
+	}
+	for _, file := range report.Conflicts {
+		fmt.Println(ColorYellow + "  ! " + file + ColorReset + " (changed in the project and the template, see " + file + conflictSuffix + ")")
+	}
+	for _, file := range report.Declined {
+		fmt.Println("  · " + file + " (declined, offered again by the next update)")
+	}
+
+	fmt.Println()
+	fmt.Printf("%d added, %d updated, %d conflicts, %d declined\n", len(report.Added), len(report.Updated), len(report.Conflicts), len(report.Declined))
+	if len(report.Conflicts) > 0 {
+		fmt.Println(ColorYellow + "Merge each " + conflictSuffix + " file into its original by hand, then delete it." + ColorReset)
+	}
+}